@@ -0,0 +1,170 @@
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/onozaty/reporg/internal/glob"
+)
+
+// Paths lists the locations of the CODEOWNERS file relative to the repository root,
+// in the order GitHub looks them up. Only the first file found is used.
+var Paths = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// Rule is a single line of a CODEOWNERS file.
+type Rule struct {
+	Pattern  string   // Path pattern as written in the file
+	Owners   []string // Users, teams or email addresses (empty = no owner)
+	compiled *compiledPattern
+}
+
+// Ruleset is a parsed CODEOWNERS file.
+type Ruleset struct {
+	rules []Rule
+}
+
+// Parse parses a CODEOWNERS file.
+// Blank lines and comments are ignored, and lines with invalid patterns are skipped as GitHub does.
+func Parse(r io.Reader) (*Ruleset, error) {
+	ruleset := &Ruleset{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := splitLine(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		compiled, err := compilePattern(fields[0])
+		if err != nil {
+			continue
+		}
+
+		ruleset.rules = append(ruleset.rules, Rule{
+			Pattern:  fields[0],
+			Owners:   fields[1:],
+			compiled: compiled,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read CODEOWNERS: %w", err)
+	}
+
+	return ruleset, nil
+}
+
+// Load reads the CODEOWNERS file of the repository from the first location in Paths that exists.
+// Returns an empty Ruleset if the repository has no CODEOWNERS file.
+func Load(repoRoot string) (*Ruleset, error) {
+	for _, path := range Paths {
+		file, err := os.Open(filepath.Join(repoRoot, filepath.FromSlash(path)))
+		if err != nil {
+			continue
+		}
+		defer file.Close()
+
+		return Parse(file)
+	}
+
+	return &Ruleset{}, nil
+}
+
+// Owners returns the owners of the file at relPath (relative to the repository root).
+// The last matching rule wins. Returns nil if no rule matches or the matching rule has no owners.
+func (rs *Ruleset) Owners(relPath string) []string {
+	relPath = filepath.ToSlash(relPath)
+
+	for i := len(rs.rules) - 1; i >= 0; i-- {
+		if rs.rules[i].compiled.match(relPath) {
+			return rs.rules[i].Owners
+		}
+	}
+
+	return nil
+}
+
+// splitLine splits a CODEOWNERS line into the pattern and owners, removing comments.
+// A "#" starts a comment unless it is escaped as "\#".
+func splitLine(line string) []string {
+	var fields []string
+	var current strings.Builder
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line) && line[i+1] == '#':
+			current.WriteByte('#')
+			i++
+		case c == '#':
+			i = len(line)
+		case c == ' ' || c == '\t':
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteByte(c)
+		}
+	}
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+
+	return fields
+}
+
+// compiledPattern matches file paths against a CODEOWNERS pattern.
+type compiledPattern struct {
+	glob      *glob.Glob
+	dirOnly   bool // Only files under a matching directory (trailing "/")
+	filesOnly bool // Only files directly in the directory (trailing "/*")
+}
+
+// compilePattern compiles a CODEOWNERS pattern.
+//
+// The semantics follow GitHub's CODEOWNERS, which are based on .gitignore and shared with
+// ripgrep's globs (see glob.Compile), except that:
+//   - A trailing "/" matches only directories.
+//   - A trailing "/*" matches only files directly in the directory, not in its subdirectories.
+//   - Negation ("!") and character ranges ("[ ]") are not supported, and "{ }" is literal.
+func compilePattern(pattern string) (*compiledPattern, error) {
+	if pattern == "" || strings.HasPrefix(pattern, "!") || strings.ContainsAny(pattern, "[]") {
+		return nil, fmt.Errorf("unsupported pattern: %s", pattern)
+	}
+
+	escaped := strings.NewReplacer("{", `\{`, "}", `\}`).Replace(pattern)
+	g, err := glob.Compile(escaped)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported pattern: %s", pattern)
+	}
+
+	return &compiledPattern{
+		glob:      g,
+		dirOnly:   strings.HasSuffix(pattern, "/"),
+		filesOnly: strings.HasSuffix(pattern, "/*"),
+	}, nil
+}
+
+// match reports whether the pattern matches the file at relPath (slash-separated).
+func (p *compiledPattern) match(relPath string) bool {
+	switch {
+	case p.dirOnly:
+		dir := path.Dir(relPath)
+		return dir != "." && p.glob.Match(dir)
+	case p.filesOnly:
+		return p.glob.MatchExact(relPath)
+	default:
+		// The file itself, or files under the matching directory
+		return p.glob.Match(relPath)
+	}
+}
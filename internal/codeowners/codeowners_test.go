@@ -0,0 +1,178 @@
+package codeowners
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompilePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		// Unanchored patterns match at any depth
+		{pattern: "*.js", path: "app.js", want: true},
+		{pattern: "*.js", path: "src/lib/app.js", want: true},
+		{pattern: "*.js", path: "app.jsx", want: false},
+		{pattern: "docs", path: "docs/readme.md", want: true},
+		{pattern: "docs", path: "src/docs/readme.md", want: true},
+		{pattern: "apps/", path: "src/apps/main.go", want: true},
+		{pattern: "apps/", path: "apps", want: false},
+
+		// Leading or middle slash anchors to the root
+		{pattern: "/build/logs/", path: "build/logs/out.log", want: true},
+		{pattern: "/build/logs/", path: "src/build/logs/out.log", want: false},
+		{pattern: "apps/github", path: "apps/github/main.go", want: true},
+		{pattern: "apps/github", path: "src/apps/github/main.go", want: false},
+		{pattern: "/README.md", path: "README.md", want: true},
+		{pattern: "/README.md", path: "docs/README.md", want: false},
+
+		// "*" does not cross directories
+		{pattern: "docs/*", path: "docs/getting-started.md", want: true},
+		{pattern: "docs/*", path: "docs/build-app/troubleshooting.md", want: false},
+
+		// "**" crosses directories
+		{pattern: "**/logs", path: "deeply/nested/logs/out.log", want: true},
+		{pattern: "/docs/**/*.md", path: "docs/a/b/c.md", want: true},
+		{pattern: "/docs/**/*.md", path: "docs/c.md", want: true},
+		{pattern: "/docs/**/*.md", path: "docs/c.txt", want: false},
+
+		// Only files directly in the directory
+		{pattern: "**/x/*", path: "x/x/y", want: true},
+
+		// "{ }" is literal
+		{pattern: "{a,b}.txt", path: "{a,b}.txt", want: true},
+		{pattern: "{a,b}.txt", path: "a.txt", want: false},
+
+		// "?" matches a single character
+		{pattern: "file?.txt", path: "file1.txt", want: true},
+		{pattern: "file?.txt", path: "file10.txt", want: false},
+
+		// Everything
+		{pattern: "*", path: "any/file.go", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			p, err := compilePattern(tt.pattern)
+			if err != nil {
+				t.Fatalf("compilePattern() error = %v, want nil", err)
+			}
+			if got := p.match(tt.path); got != tt.want {
+				t.Errorf("compilePattern(%q) match %q = %v, want %v", tt.pattern, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompilePattern_Unsupported(t *testing.T) {
+	for _, pattern := range []string{"!*.js", "[abc].js", "/"} {
+		t.Run(pattern, func(t *testing.T) {
+			if _, err := compilePattern(pattern); err == nil {
+				t.Errorf("compilePattern(%q) expected error, got nil", pattern)
+			}
+		})
+	}
+}
+
+func TestRuleset_Owners(t *testing.T) {
+	content := `# Default owners
+*       @org/everyone
+
+*.js    @org/frontend   # JavaScript files
+/docs/  docs@example.com @writer
+/docs/internal/
+\#notes @org/notes
+apps/   @org/apps
+`
+	ruleset, err := Parse(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v, want nil", err)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: "main.go", want: []string{"@org/everyone"}},
+		{path: "web/app.js", want: []string{"@org/frontend"}},
+		{path: "docs/guide.md", want: []string{"docs@example.com", "@writer"}},
+		{path: "docs/internal/secret.md", want: nil}, // Last match wins, and it has no owners
+		{path: "#notes", want: []string{"@org/notes"}},
+		{path: "apps/web/app.js", want: []string{"@org/apps"}},
+		{path: filepath.Join("web", "app.js"), want: []string{"@org/frontend"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := ruleset.Owners(tt.path)
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("Owners(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name:  ".github directory",
+			files: map[string]string{".github/CODEOWNERS": "* @github"},
+			want:  []string{"@github"},
+		},
+		{
+			name:  "root",
+			files: map[string]string{"CODEOWNERS": "* @root"},
+			want:  []string{"@root"},
+		},
+		{
+			name:  "docs directory",
+			files: map[string]string{"docs/CODEOWNERS": "* @docs"},
+			want:  []string{"@docs"},
+		},
+		{
+			name: ".github directory takes precedence",
+			files: map[string]string{
+				".github/CODEOWNERS": "* @github",
+				"CODEOWNERS":         "* @root",
+				"docs/CODEOWNERS":    "* @docs",
+			},
+			want: []string{"@github"},
+		},
+		{
+			name:  "no CODEOWNERS",
+			files: map[string]string{},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoDir := t.TempDir()
+			for path, content := range tt.files {
+				fullPath := filepath.Join(repoDir, filepath.FromSlash(path))
+				os.MkdirAll(filepath.Dir(fullPath), 0755)
+				if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write file: %v", err)
+				}
+			}
+
+			ruleset, err := Load(repoDir)
+			if err != nil {
+				t.Fatalf("Load() error = %v, want nil", err)
+			}
+
+			got := ruleset.Owners("main.go")
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("Owners() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// HostConfig describes a custom Git hosting server (e.g., GitHub Enterprise Server).
+type HostConfig struct {
+	Type    string `json:"type"`     // Provider type ("github" or "gitlab")
+	Host    string `json:"host"`     // Host name used in remote URLs (e.g., "ghe.corp.example")
+	BaseURL string `json:"base_url"` // Web base URL (default: "https://<host>")
+}
+
+// Config represents the contents of a reporg configuration file.
+type Config struct {
+	Hosts []HostConfig `json:"hosts"` // Additional hosting servers
+}
+
+// Load reads a JSON configuration file.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
+
+// ParseHostFlag parses a host definition in "TYPE:HOST[=BASE_URL]" format.
+// e.g., "github:ghe.corp.example" or "gitlab:git.corp.example=https://git.corp.example/gitlab"
+func ParseHostFlag(value string) (HostConfig, error) {
+	providerType, rest, found := strings.Cut(value, ":")
+	if !found || providerType == "" || rest == "" {
+		return HostConfig{}, fmt.Errorf("invalid host definition (expected TYPE:HOST[=BASE_URL]): %s", value)
+	}
+
+	host, baseURL, _ := strings.Cut(rest, "=")
+	if host == "" {
+		return HostConfig{}, fmt.Errorf("invalid host definition (expected TYPE:HOST[=BASE_URL]): %s", value)
+	}
+
+	return HostConfig{
+		Type:    providerType,
+		Host:    host,
+		BaseURL: baseURL,
+	}, nil
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+	content := `{
+  "hosts": [
+    {"type": "github", "host": "ghe.corp.example", "base_url": "https://ghe.corp.example"},
+    {"type": "gitlab", "host": "git.corp.example"}
+  ]
+}`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+
+	if len(cfg.Hosts) != 2 {
+		t.Fatalf("Load() returned %d hosts, want 2", len(cfg.Hosts))
+	}
+
+	want := HostConfig{Type: "github", Host: "ghe.corp.example", BaseURL: "https://ghe.corp.example"}
+	if cfg.Hosts[0] != want {
+		t.Errorf("Load() hosts[0] = %v, want %v", cfg.Hosts[0], want)
+	}
+
+	want = HostConfig{Type: "gitlab", Host: "git.corp.example", BaseURL: ""}
+	if cfg.Hosts[1] != want {
+		t.Errorf("Load() hosts[1] = %v, want %v", cfg.Hosts[1], want)
+	}
+}
+
+func TestLoad_NotFound(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "nonexistent.json"))
+	if err == nil {
+		t.Error("Load() expected error for nonexistent file, got nil")
+	}
+}
+
+func TestLoad_InvalidJSON(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte("{invalid"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	_, err := Load(configPath)
+	if err == nil {
+		t.Error("Load() expected error for invalid JSON, got nil")
+	}
+}
+
+func TestParseHostFlag(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    HostConfig
+		wantErr bool
+	}{
+		{
+			name:  "Type and host",
+			value: "github:ghe.corp.example",
+			want:  HostConfig{Type: "github", Host: "ghe.corp.example"},
+		},
+		{
+			name:  "Type, host and base URL",
+			value: "gitlab:git.corp.example=https://git.corp.example/gitlab",
+			want:  HostConfig{Type: "gitlab", Host: "git.corp.example", BaseURL: "https://git.corp.example/gitlab"},
+		},
+		{
+			name:    "Missing type",
+			value:   "ghe.corp.example",
+			wantErr: true,
+		},
+		{
+			name:    "Missing host",
+			value:   "github:",
+			wantErr: true,
+		},
+		{
+			name:    "Missing host with base URL",
+			value:   "github:=https://ghe.corp.example",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHostFlag(tt.value)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseHostFlag() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseHostFlag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BlameInfo describes the commit that last changed a line.
+type BlameInfo struct {
+	Commit      string // Full commit SHA (all zeros for uncommitted lines)
+	Author      string // Author name
+	AuthorEmail string // Author email address
+	CommitDate  string // Committer date in RFC 3339 format
+}
+
+// Blamer annotates lines of files with git blame.
+// Each file is blamed once and the result is cached, so files with many matches
+// run git blame only once.
+type Blamer struct {
+	repoRoot string
+	rev      string                       // Revision to blame (empty = working tree)
+	cache    map[string]map[int]BlameInfo // Blame results by file and line number
+}
+
+// NewBlamer creates a Blamer for files at rev. If rev is empty, files in the working tree are
+// blamed, including uncommitted changes.
+func NewBlamer(repoRoot, rev string) *Blamer {
+	return &Blamer{
+		repoRoot: repoRoot,
+		rev:      rev,
+		cache:    make(map[string]map[int]BlameInfo),
+	}
+}
+
+// Blame returns the blame information for a line of the file at relPath.
+// Returns false if the file cannot be blamed (e.g., it is not tracked by git).
+func (b *Blamer) Blame(relPath string, lineNum int) (BlameInfo, bool) {
+	relPath = filepath.ToSlash(relPath)
+
+	lines, ok := b.cache[relPath]
+	if !ok {
+		var err error
+		lines, err = b.blameFile(relPath)
+		if err != nil {
+			// Cache the failure so the file is not blamed again
+			lines = nil
+		}
+		b.cache[relPath] = lines
+	}
+
+	info, ok := lines[lineNum]
+	return info, ok
+}
+
+// blameFile runs git blame on a whole file and returns the blame information by line number.
+func (b *Blamer) blameFile(relPath string) (map[int]BlameInfo, error) {
+	args := []string{"-C", b.repoRoot, "blame", "--line-porcelain"}
+	if b.rev != "" {
+		args = append(args, b.rev)
+	}
+	args = append(args, "--", relPath)
+
+	// Execute: git -C <repoRoot> blame --line-porcelain [<rev>] -- <relPath>
+	cmd := exec.Command("git", args...)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to blame %s: %w", relPath, err)
+	}
+
+	return parseLinePorcelain(string(output)), nil
+}
+
+// parseLinePorcelain parses the output of git blame --line-porcelain.
+// Each line of the file is preceded by a header "<sha> <orig line> <final line> [<count>]"
+// and the commit information, and the content line itself starts with a tab.
+func parseLinePorcelain(output string) map[int]BlameInfo {
+	lines := make(map[int]BlameInfo)
+
+	var (
+		current       BlameInfo
+		finalLine     int
+		committerTime int64
+		committerTZ   string
+		expectHeader  = true
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if expectHeader {
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				continue
+			}
+			current = BlameInfo{Commit: fields[0]}
+			finalLine, _ = strconv.Atoi(fields[2])
+			committerTime, committerTZ = 0, ""
+			expectHeader = false
+			continue
+		}
+
+		if strings.HasPrefix(line, "\t") {
+			// Content line ends the entry
+			current.CommitDate = formatGitTime(committerTime, committerTZ)
+			lines[finalLine] = current
+			expectHeader = true
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "author":
+			current.Author = value
+		case "author-mail":
+			current.AuthorEmail = strings.TrimSuffix(strings.TrimPrefix(value, "<"), ">")
+		case "committer-time":
+			committerTime, _ = strconv.ParseInt(value, 10, 64)
+		case "committer-tz":
+			committerTZ = value
+		}
+	}
+
+	return lines
+}
+
+// formatGitTime formats a Unix timestamp and a git timezone offset (e.g., "+0900") in RFC 3339 format.
+func formatGitTime(unix int64, tz string) string {
+	offset := 0
+	if len(tz) == 5 {
+		hours, errH := strconv.Atoi(tz[1:3])
+		minutes, errM := strconv.Atoi(tz[3:5])
+		if errH == nil && errM == nil {
+			offset = (hours*60 + minutes) * 60
+			if tz[0] == '-' {
+				offset = -offset
+			}
+		}
+	}
+
+	return time.Unix(unix, 0).In(time.FixedZone("", offset)).Format(time.RFC3339)
+}
@@ -0,0 +1,137 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestBlamer_Blame(t *testing.T) {
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+
+	commitTestFile(t, tmpDir, "file.txt", "line1\nline2\n")
+	firstCommit, _ := GetHeadCommit(tmpDir)
+
+	// Commit a change to the second line as another author
+	os.WriteFile(filepath.Join(tmpDir, "file.txt"), []byte("line1\nchanged\n"), 0644)
+	exec.Command("git", "-C", tmpDir, "add", "file.txt").Run()
+	cmd := exec.Command("git", "-C", tmpDir, "commit", "-m", "Change line2")
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Other User", "GIT_AUTHOR_EMAIL=other@example.com",
+		"GIT_COMMITTER_DATE=2024-01-02T03:04:05+09:00")
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+	secondCommit, _ := GetHeadCommit(tmpDir)
+
+	// Uncommitted third line
+	os.WriteFile(filepath.Join(tmpDir, "file.txt"), []byte("line1\nchanged\nlocal\n"), 0644)
+
+	blamer := NewBlamer(tmpDir, "")
+
+	info, ok := blamer.Blame("file.txt", 1)
+	if !ok {
+		t.Fatal("Blame() ok = false, want true")
+	}
+	if info.Commit != firstCommit || info.Author != "Test User" || info.AuthorEmail != "test@example.com" {
+		t.Errorf("Blame(1) = %+v, want commit %s by Test User <test@example.com>", info, firstCommit)
+	}
+
+	info, ok = blamer.Blame("file.txt", 2)
+	if !ok {
+		t.Fatal("Blame() ok = false, want true")
+	}
+	want := BlameInfo{
+		Commit:      secondCommit,
+		Author:      "Other User",
+		AuthorEmail: "other@example.com",
+		CommitDate:  "2024-01-02T03:04:05+09:00",
+	}
+	if info != want {
+		t.Errorf("Blame(2) = %+v, want %+v", info, want)
+	}
+
+	info, ok = blamer.Blame("file.txt", 3)
+	if !ok {
+		t.Fatal("Blame() ok = false, want true")
+	}
+	if info.Commit != "0000000000000000000000000000000000000000" {
+		t.Errorf("Blame(3) commit = %v, want zeros for uncommitted line", info.Commit)
+	}
+
+	if _, ok := blamer.Blame("file.txt", 10); ok {
+		t.Error("Blame() ok = true for a line out of range, want false")
+	}
+}
+
+func TestBlamer_Revision(t *testing.T) {
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+
+	commitTestFile(t, tmpDir, "file.txt", "old\n")
+	firstCommit, _ := GetHeadCommit(tmpDir)
+	commitTestFile(t, tmpDir, "file.txt", "new\n")
+
+	blamer := NewBlamer(tmpDir, firstCommit)
+
+	info, ok := blamer.Blame("file.txt", 1)
+	if !ok {
+		t.Fatal("Blame() ok = false, want true")
+	}
+	if info.Commit != firstCommit {
+		t.Errorf("Blame() commit = %v, want %v", info.Commit, firstCommit)
+	}
+}
+
+func TestBlamer_UntrackedFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+	os.WriteFile(filepath.Join(tmpDir, "untracked.txt"), []byte("content\n"), 0644)
+
+	blamer := NewBlamer(tmpDir, "")
+
+	if _, ok := blamer.Blame("untracked.txt", 1); ok {
+		t.Error("Blame() ok = true for an untracked file, want false")
+	}
+}
+
+func TestBlamer_CachesPerFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+	commitTestFile(t, tmpDir, "file.txt", "line1\nline2\n")
+
+	blamer := NewBlamer(tmpDir, "")
+	blamer.Blame("file.txt", 1)
+
+	// Changes after the first blame are not seen because the result is cached
+	commitTestFile(t, tmpDir, "file.txt", "line1\nline2\nline3\n")
+
+	if _, ok := blamer.Blame("file.txt", 3); ok {
+		t.Error("Blame() should use the cached result for the file")
+	}
+	if len(blamer.cache) != 1 {
+		t.Errorf("cache size = %d, want 1", len(blamer.cache))
+	}
+}
+
+func TestFormatGitTime(t *testing.T) {
+	tests := []struct {
+		unix int64
+		tz   string
+		want string
+	}{
+		{unix: 1704132245, tz: "+0900", want: "2024-01-02T03:04:05+09:00"},
+		{unix: 1704132245, tz: "-0530", want: "2024-01-01T12:34:05-05:30"},
+		{unix: 1704132245, tz: "+0000", want: "2024-01-01T18:04:05Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tz, func(t *testing.T) {
+			if got := formatGitTime(tt.unix, tt.tz); got != tt.want {
+				t.Errorf("formatGitTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
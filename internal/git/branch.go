@@ -0,0 +1,61 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Branch is a local or remote-tracking branch.
+type Branch struct {
+	Name   string // Short name (e.g., "release/1.0" or "origin/release/1.0")
+	Remote string // Remote of a remote-tracking branch (empty for local branches)
+	Commit string // Commit SHA the branch points to
+}
+
+// RemoteBranch returns the branch name on the remote for a remote-tracking branch
+// (e.g., "release/1.0" for "origin/release/1.0"), or the name of a local branch.
+func (b Branch) RemoteBranch() string {
+	if b.Remote == "" {
+		return b.Name
+	}
+	return strings.TrimPrefix(b.Name, b.Remote+"/")
+}
+
+// ListBranches returns the local and remote-tracking branches whose names match any of
+// the given patterns. Patterns are matched against the branch name without the remote
+// (e.g., "release/*" matches both "release/1.0" and "origin/release/1.0"); "*" does not
+// match "/". Symbolic refs such as origin/HEAD are skipped.
+func ListBranches(repoRoot string, patterns []string) ([]Branch, error) {
+	args := []string{"-C", repoRoot, "for-each-ref", "--format=%(refname) %(objectname) %(symref)"}
+	for _, pattern := range patterns {
+		args = append(args, "refs/heads/"+pattern, "refs/remotes/*/"+pattern)
+	}
+
+	// Execute: git -C <repoRoot> for-each-ref --format=... refs/heads/<pattern> refs/remotes/*/<pattern> ...
+	cmd := exec.Command("git", args...)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	var branches []Branch
+	for _, line := range strings.Split(string(output), "\n") {
+		// Ref names cannot contain spaces
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			// Empty line or symbolic ref
+			continue
+		}
+		refName, commit := fields[0], fields[1]
+
+		if name, found := strings.CutPrefix(refName, "refs/heads/"); found {
+			branches = append(branches, Branch{Name: name, Commit: commit})
+		} else if name, found := strings.CutPrefix(refName, "refs/remotes/"); found {
+			remote, _, _ := strings.Cut(name, "/")
+			branches = append(branches, Branch{Name: name, Remote: remote, Commit: commit})
+		}
+	}
+
+	return branches, nil
+}
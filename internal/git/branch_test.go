@@ -0,0 +1,94 @@
+package git
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestListBranches(t *testing.T) {
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+	head, _ := GetHeadCommit(tmpDir)
+
+	// Rename the initial branch so that the result does not depend on init.defaultBranch
+	exec.Command("git", "-C", tmpDir, "branch", "-M", "trunk").Run()
+	exec.Command("git", "-C", tmpDir, "branch", "release/1.0").Run()
+	exec.Command("git", "-C", tmpDir, "branch", "release/2.0").Run()
+	exec.Command("git", "-C", tmpDir, "branch", "feature/a").Run()
+	exec.Command("git", "-C", tmpDir, "update-ref", "refs/remotes/origin/release/3.0", "HEAD").Run()
+	exec.Command("git", "-C", tmpDir, "update-ref", "refs/remotes/origin/main", "HEAD").Run()
+	exec.Command("git", "-C", tmpDir, "symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/main").Run()
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []Branch
+	}{
+		{
+			name:     "release branches",
+			patterns: []string{"release/*"},
+			want: []Branch{
+				{Name: "release/1.0", Commit: head},
+				{Name: "release/2.0", Commit: head},
+				{Name: "origin/release/3.0", Remote: "origin", Commit: head},
+			},
+		},
+		{
+			name:     "multiple patterns",
+			patterns: []string{"feature/*", "main"},
+			want: []Branch{
+				{Name: "feature/a", Commit: head},
+				{Name: "origin/main", Remote: "origin", Commit: head},
+			},
+		},
+		{
+			name:     "star does not match slash and symbolic refs are skipped",
+			patterns: []string{"*"},
+			want: []Branch{
+				{Name: "trunk", Commit: head},
+				{Name: "origin/main", Remote: "origin", Commit: head},
+			},
+		},
+		{
+			name:     "no match",
+			patterns: []string{"hotfix/*"},
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ListBranches(tmpDir, tt.patterns)
+			if err != nil {
+				t.Fatalf("ListBranches() error = %v, want nil", err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("ListBranches() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("ListBranches()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBranch_RemoteBranch(t *testing.T) {
+	tests := []struct {
+		branch Branch
+		want   string
+	}{
+		{branch: Branch{Name: "release/1.0"}, want: "release/1.0"},
+		{branch: Branch{Name: "origin/release/1.0", Remote: "origin"}, want: "release/1.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch.Name, func(t *testing.T) {
+			if got := tt.branch.RemoteBranch(); got != tt.want {
+				t.Errorf("RemoteBranch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ChangeSet describes the files and lines changed since a base revision.
+type ChangeSet struct {
+	Base       string                  // Merge base commit the changes are computed from
+	Files      []string                // Changed files (slash-separated, excluding deleted files)
+	addedLines map[string]map[int]bool // Added lines by file and line number in the new content
+}
+
+// GetChanges returns the changes between the merge base of ref and head, and head.
+// If head is empty, the working tree is compared instead, which includes staged and unstaged
+// changes (untracked files are not included).
+func GetChanges(repoRoot, ref, head string) (*ChangeSet, error) {
+	mergeBaseTip := head
+	if mergeBaseTip == "" {
+		mergeBaseTip = "HEAD"
+	}
+
+	// Execute: git -C <repoRoot> merge-base <ref> <head>
+	cmd := exec.Command("git", "-C", repoRoot, "merge-base", ref, mergeBaseTip)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base of %s and %s", ref, mergeBaseTip)
+	}
+	base := strings.TrimSpace(string(output))
+
+	diffArgs := []string{base}
+	if head != "" {
+		diffArgs = append(diffArgs, head)
+	}
+
+	// Execute: git -C <repoRoot> diff --name-only -z --diff-filter=d <base> [<head>]
+	args := append([]string{"-C", repoRoot, "diff", "--name-only", "-z", "--diff-filter=d", "--no-ext-diff"}, diffArgs...)
+	output, err = exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed files: %w", err)
+	}
+
+	changes := &ChangeSet{
+		Base:       base,
+		Files:      []string{},
+		addedLines: make(map[string]map[int]bool),
+	}
+	for _, path := range strings.Split(string(output), "\x00") {
+		if path != "" {
+			changes.Files = append(changes.Files, path)
+		}
+	}
+
+	// Execute: git -C <repoRoot> diff -U0 <base> [<head>]
+	args = append([]string{"-C", repoRoot, "-c", "core.quotePath=false", "diff", "-U0", "--no-color", "--no-ext-diff", "--src-prefix=a/", "--dst-prefix=b/"}, diffArgs...)
+	output, err = exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff against %s: %w", base, err)
+	}
+	changes.addedLines = parseAddedLines(string(output))
+
+	return changes, nil
+}
+
+// IsAdded reports whether the line of the file at relPath was added or modified by the changes.
+func (c *ChangeSet) IsAdded(relPath string, lineNum int) bool {
+	return c.addedLines[filepath.ToSlash(relPath)][lineNum]
+}
+
+// parseAddedLines parses a unified diff with no context lines (-U0) and returns
+// the added line numbers of each file in its new content.
+func parseAddedLines(diff string) map[string]map[int]bool {
+	added := make(map[string]map[int]bool)
+
+	var currentFile string
+	inHunk := false
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "diff "):
+			currentFile = ""
+			inHunk = false
+
+		case !inHunk && strings.HasPrefix(line, "+++ "):
+			// git terminates paths containing spaces with a tab
+			path := strings.TrimSuffix(strings.TrimPrefix(line, "+++ "), "\t")
+			if strings.HasPrefix(path, `"`) {
+				if unquoted, err := strconv.Unquote(path); err == nil {
+					path = unquoted
+				}
+			}
+			if path == "/dev/null" {
+				currentFile = ""
+			} else {
+				currentFile = strings.TrimPrefix(path, "b/")
+			}
+
+		case strings.HasPrefix(line, "@@ ") && currentFile != "":
+			// Hunk header: "@@ -<old>[,<count>] +<new>[,<count>] @@"
+			inHunk = true
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				continue
+			}
+			start, count, err := parseHunkRange(strings.TrimPrefix(fields[2], "+"))
+			if err != nil {
+				continue
+			}
+			if added[currentFile] == nil {
+				added[currentFile] = make(map[int]bool)
+			}
+			for i := 0; i < count; i++ {
+				added[currentFile][start+i] = true
+			}
+		}
+	}
+
+	return added
+}
+
+// parseHunkRange parses a hunk range ("<start>[,<count>]"). The count defaults to 1.
+func parseHunkRange(r string) (start, count int, err error) {
+	startStr, countStr, hasCount := strings.Cut(r, ",")
+
+	start, err = strconv.Atoi(startStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid hunk range: %s", r)
+	}
+
+	count = 1
+	if hasCount {
+		count, err = strconv.Atoi(countStr)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid hunk range: %s", r)
+		}
+	}
+
+	return start, count, nil
+}
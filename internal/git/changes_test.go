@@ -0,0 +1,177 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// setupChangesRepo creates a repository with a "feature" branch created from the initial branch,
+// and returns the repository path and the initial branch name
+func setupChangesRepo(t *testing.T) (string, string) {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+	commitTestFile(t, tmpDir, "base.txt", "line1\nline2\nline3\n")
+	commitTestFile(t, tmpDir, "deleted.txt", "deleted\n")
+	base, _ := GetCurrentBranch(tmpDir)
+
+	exec.Command("git", "-C", tmpDir, "checkout", "-q", "-b", "feature").Run()
+	commitTestFile(t, tmpDir, "base.txt", "line1\nchanged\nline3\nadded\n")
+	commitTestFile(t, tmpDir, "dir/new.txt", "new1\nnew2\n")
+	exec.Command("git", "-C", tmpDir, "rm", "-q", "deleted.txt").Run()
+	exec.Command("git", "-C", tmpDir, "commit", "-q", "-m", "Delete file").Run()
+
+	// Commit on the base branch after the feature branch was created
+	exec.Command("git", "-C", tmpDir, "checkout", "-q", base).Run()
+	commitTestFile(t, tmpDir, "other.txt", "other\n")
+	exec.Command("git", "-C", tmpDir, "checkout", "-q", "feature").Run()
+
+	return tmpDir, base
+}
+
+func TestGetChanges(t *testing.T) {
+	repoDir, base := setupChangesRepo(t)
+
+	// Uncommitted changes are not included when comparing with HEAD
+	os.WriteFile(filepath.Join(repoDir, "base.txt"), []byte("uncommitted\nchanged\nline3\nadded\n"), 0644)
+
+	changes, err := GetChanges(repoDir, base, "HEAD")
+	if err != nil {
+		t.Fatalf("GetChanges() error = %v, want nil", err)
+	}
+
+	wantFiles := []string{"base.txt", "dir/new.txt"}
+	if !slices.Equal(changes.Files, wantFiles) {
+		t.Errorf("Files = %v, want %v", changes.Files, wantFiles)
+	}
+
+	tests := []struct {
+		relPath string
+		line    int
+		want    bool
+	}{
+		{relPath: "base.txt", line: 1, want: false},
+		{relPath: "base.txt", line: 2, want: true},
+		{relPath: "base.txt", line: 3, want: false},
+		{relPath: "base.txt", line: 4, want: true},
+		{relPath: filepath.Join("dir", "new.txt"), line: 1, want: true},
+		{relPath: "dir/new.txt", line: 2, want: true},
+		{relPath: "dir/new.txt", line: 3, want: false},
+		{relPath: "other.txt", line: 1, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := changes.IsAdded(tt.relPath, tt.line); got != tt.want {
+			t.Errorf("IsAdded(%q, %d) = %v, want %v", tt.relPath, tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestGetChanges_WorkingTree(t *testing.T) {
+	repoDir, base := setupChangesRepo(t)
+
+	// Unstaged change
+	os.WriteFile(filepath.Join(repoDir, "base.txt"), []byte("uncommitted\nchanged\nline3\nadded\n"), 0644)
+	// Staged new file
+	os.WriteFile(filepath.Join(repoDir, "staged.txt"), []byte("staged\n"), 0644)
+	exec.Command("git", "-C", repoDir, "add", "staged.txt").Run()
+
+	changes, err := GetChanges(repoDir, base, "")
+	if err != nil {
+		t.Fatalf("GetChanges() error = %v, want nil", err)
+	}
+
+	wantFiles := []string{"base.txt", "dir/new.txt", "staged.txt"}
+	if !slices.Equal(changes.Files, wantFiles) {
+		t.Errorf("Files = %v, want %v", changes.Files, wantFiles)
+	}
+
+	if !changes.IsAdded("base.txt", 1) {
+		t.Error("IsAdded(base.txt, 1) = false, want true for unstaged change")
+	}
+	if !changes.IsAdded("staged.txt", 1) {
+		t.Error("IsAdded(staged.txt, 1) = false, want true for staged file")
+	}
+}
+
+func TestGetChanges_UnknownRef(t *testing.T) {
+	repoDir, _ := setupChangesRepo(t)
+
+	if _, err := GetChanges(repoDir, "nonexistent", "HEAD"); err == nil {
+		t.Error("GetChanges() expected error for unknown ref, got nil")
+	}
+}
+
+func TestGetChanges_PathWithSpace(t *testing.T) {
+	repoDir, base := setupChangesRepo(t)
+	// Diff prefixes are pinned, so user settings must not affect the paths
+	exec.Command("git", "-C", repoDir, "config", "diff.noprefix", "true").Run()
+	exec.Command("git", "-C", repoDir, "config", "diff.mnemonicPrefix", "true").Run()
+	commitTestFile(t, repoDir, "dir name/a b.txt", "added\n")
+
+	changes, err := GetChanges(repoDir, base, "HEAD")
+	if err != nil {
+		t.Fatalf("GetChanges() error = %v, want nil", err)
+	}
+
+	if !slices.Contains(changes.Files, "dir name/a b.txt") {
+		t.Errorf("Files = %v, want to contain %q", changes.Files, "dir name/a b.txt")
+	}
+	if !changes.IsAdded("dir name/a b.txt", 1) {
+		t.Error("IsAdded(dir name/a b.txt, 1) = false, want true")
+	}
+	if !changes.IsAdded("base.txt", 2) {
+		t.Error("IsAdded(base.txt, 2) = false, want true")
+	}
+}
+
+func TestParseAddedLines(t *testing.T) {
+	diff := `diff --git a/file.txt b/file.txt
+index 0000000..1111111 100644
+--- a/file.txt
++++ b/file.txt
+@@ -2 +2 @@ header
+-old
++new
+@@ -5,0 +6,2 @@
++++ added line starting with ++
++another
+diff --git a/removed.txt b/removed.txt
+deleted file mode 100644
+--- a/removed.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-removed
+diff --git a/with space.txt b/with space.txt
+new file mode 100644
+index 0000000..587be6b
+--- /dev/null
++++ b/with space.txt` + "\t" + `
+@@ -0,0 +1 @@
++x
+`
+	added := parseAddedLines(diff)
+
+	want := map[string][]int{
+		"file.txt":       {2, 6, 7},
+		"with space.txt": {1},
+	}
+
+	if len(added) != len(want) {
+		t.Fatalf("parseAddedLines() = %v, want files %v", added, want)
+	}
+	for file, lines := range want {
+		if len(added[file]) != len(lines) {
+			t.Errorf("parseAddedLines()[%q] = %v, want %v", file, added[file], lines)
+		}
+		for _, line := range lines {
+			if !added[file][line] {
+				t.Errorf("parseAddedLines()[%q] missing line %d", file, line)
+			}
+		}
+	}
+}
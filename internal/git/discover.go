@@ -0,0 +1,87 @@
+package git
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DiscoverOptions controls how repositories are discovered under parent directories.
+type DiscoverOptions struct {
+	MaxDepth    int      // Maximum depth of repository roots below each parent directory (0 = the parent itself only)
+	ExcludeDirs []string // Directory name patterns that are not descended into (e.g., "node_modules")
+}
+
+// DiscoverRepos walks the parent directories and returns the repository roots found under them,
+// in lexical order. A directory is a repository root if it contains ".git" (a directory, or a file
+// for worktrees and submodules). Repositories nested inside a found repository are not collected.
+func DiscoverRepos(parents []string, opts DiscoverOptions) ([]string, error) {
+	var repos []string
+
+	for _, parent := range parents {
+		info, err := os.Stat(parent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to access directory: %w", err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("not a directory: %s", parent)
+		}
+
+		err = filepath.WalkDir(parent, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if path == parent {
+					return err
+				}
+				// Skip unreadable directories
+				return filepath.SkipDir
+			}
+			if !d.IsDir() {
+				return nil
+			}
+
+			if path != parent && isExcludedDir(d.Name(), opts.ExcludeDirs) {
+				return filepath.SkipDir
+			}
+
+			if _, err := os.Lstat(filepath.Join(path, ".git")); err == nil {
+				repos = append(repos, path)
+				return filepath.SkipDir
+			}
+
+			if depth(parent, path) >= opts.MaxDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover repositories in %s: %w", parent, err)
+		}
+	}
+
+	return repos, nil
+}
+
+// isExcludedDir reports whether the directory name matches any of the exclude patterns.
+// The ".git" directory is always excluded.
+func isExcludedDir(name string, patterns []string) bool {
+	if name == ".git" {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// depth returns the number of path segments of path below parent.
+func depth(parent, path string) int {
+	rel, err := filepath.Rel(parent, path)
+	if err != nil || rel == "." {
+		return 0
+	}
+	return strings.Count(rel, string(filepath.Separator)) + 1
+}
@@ -0,0 +1,125 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// makeRepoDir creates a directory that looks like a repository root (contains .git)
+func makeRepoDir(t *testing.T, dir string, gitFile bool) {
+	t.Helper()
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if gitFile {
+		// Worktrees and submodules have a .git file
+		os.WriteFile(filepath.Join(dir, ".git"), []byte("gitdir: /somewhere\n"), 0644)
+	} else {
+		os.MkdirAll(filepath.Join(dir, ".git"), 0755)
+	}
+}
+
+func TestDiscoverRepos(t *testing.T) {
+	parent := t.TempDir()
+	makeRepoDir(t, filepath.Join(parent, "api"), false)
+	makeRepoDir(t, filepath.Join(parent, "team", "web"), false)
+	makeRepoDir(t, filepath.Join(parent, "team", "worktree"), true)
+	makeRepoDir(t, filepath.Join(parent, "team", "deep", "too", "far"), false)
+	makeRepoDir(t, filepath.Join(parent, "api", "nested"), false)
+	makeRepoDir(t, filepath.Join(parent, "web", "node_modules", "pkg"), false)
+	makeRepoDir(t, filepath.Join(parent, "archive", "old"), false)
+	os.WriteFile(filepath.Join(parent, "notes.txt"), []byte("notes\n"), 0644)
+
+	tests := []struct {
+		name string
+		opts DiscoverOptions
+		want []string
+	}{
+		{
+			name: "depth 2",
+			opts: DiscoverOptions{MaxDepth: 2, ExcludeDirs: []string{"node_modules"}},
+			want: []string{"api", "archive/old", "team/web", "team/worktree"},
+		},
+		{
+			name: "depth 1",
+			opts: DiscoverOptions{MaxDepth: 1, ExcludeDirs: []string{"node_modules"}},
+			want: []string{"api"},
+		},
+		{
+			name: "deeper",
+			opts: DiscoverOptions{MaxDepth: 4, ExcludeDirs: []string{"node_modules"}},
+			want: []string{"api", "archive/old", "team/deep/too/far", "team/web", "team/worktree"},
+		},
+		{
+			name: "exclude pattern",
+			opts: DiscoverOptions{MaxDepth: 3, ExcludeDirs: []string{"node_modules", "arch*"}},
+			want: []string{"api", "team/web", "team/worktree"},
+		},
+		{
+			name: "no excludes",
+			opts: DiscoverOptions{MaxDepth: 3},
+			want: []string{"api", "archive/old", "team/web", "team/worktree", "web/node_modules/pkg"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos, err := DiscoverRepos([]string{parent}, tt.opts)
+			if err != nil {
+				t.Fatalf("DiscoverRepos() error = %v, want nil", err)
+			}
+
+			var got []string
+			for _, repo := range repos {
+				rel, _ := filepath.Rel(parent, repo)
+				got = append(got, filepath.ToSlash(rel))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("DiscoverRepos() = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("DiscoverRepos()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDiscoverRepos_ParentIsRepository(t *testing.T) {
+	parent := t.TempDir()
+	makeRepoDir(t, parent, false)
+	makeRepoDir(t, filepath.Join(parent, "nested"), false)
+
+	repos, err := DiscoverRepos([]string{parent}, DiscoverOptions{MaxDepth: 3})
+	if err != nil {
+		t.Fatalf("DiscoverRepos() error = %v, want nil", err)
+	}
+	if len(repos) != 1 || repos[0] != parent {
+		t.Errorf("DiscoverRepos() = %v, want [%v]", repos, parent)
+	}
+}
+
+func TestDiscoverRepos_InvalidParent(t *testing.T) {
+	parent := t.TempDir()
+	file := filepath.Join(parent, "file.txt")
+	os.WriteFile(file, []byte("content\n"), 0644)
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "not found", path: filepath.Join(parent, "nonexistent")},
+		{name: "file", path: file},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DiscoverRepos([]string{tt.path}, DiscoverOptions{MaxDepth: 3}); err == nil {
+				t.Error("DiscoverRepos() expected error, got nil")
+			}
+		})
+	}
+}
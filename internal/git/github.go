@@ -2,54 +2,75 @@ package git
 
 import (
 	"fmt"
-	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strings"
 )
 
-var (
-	// Regex patterns for GitHub URLs
-	httpsPattern = regexp.MustCompile(`^https://github\.com/([^/]+)/([^/]+?)(?:\.git)?$`)
-	sshPattern   = regexp.MustCompile(`^git@github\.com:([^/]+)/([^/]+?)(?:\.git)?$`)
+const (
+	// gitHubHost is the host name of github.com
+	gitHubHost = "github.com"
 )
 
-// GetGitHubRemoteURL returns the origin remote URL for the repository.
-func GetGitHubRemoteURL(repoRoot string) (string, error) {
-	// Execute: git -C <repoRoot> remote get-url origin
-	cmd := exec.Command("git", "-C", repoRoot, "remote", "get-url", "origin")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", fmt.Errorf("failed to get remote URL: %w", err)
-	}
+// defaultGitHub is the provider for github.com
+var defaultGitHub = NewGitHubProvider(gitHubHost, "")
 
-	remoteURL := strings.TrimSpace(string(output))
-	return remoteURL, nil
+// GitHubProvider is the Provider implementation for github.com and GitHub Enterprise Server.
+type GitHubProvider struct {
+	host    string
+	baseURL string
 }
 
-// ParseGitHubURL parses a GitHub remote URL and extracts the owner and repository name.
-// Supports both HTTPS and SSH formats.
-func ParseGitHubURL(remoteURL string) (owner, repo string, err error) {
-	// Try HTTPS pattern first
-	if matches := httpsPattern.FindStringSubmatch(remoteURL); matches != nil {
-		return matches[1], matches[2], nil
+// NewGitHubProvider creates a GitHubProvider for the given host.
+// baseURL is the web base URL (e.g., "https://ghe.example.com"); if empty, "https://<host>" is used.
+func NewGitHubProvider(host, baseURL string) *GitHubProvider {
+	if baseURL == "" {
+		baseURL = "https://" + host
+	}
+
+	return &GitHubProvider{
+		host:    strings.ToLower(host),
+		baseURL: strings.TrimSuffix(baseURL, "/"),
 	}
+}
+
+// Name returns the provider name.
+func (p *GitHubProvider) Name() string {
+	return "github"
+}
 
-	// Try SSH pattern
-	if matches := sshPattern.FindStringSubmatch(remoteURL); matches != nil {
-		return matches[1], matches[2], nil
+// ParseRemoteURL parses a GitHub remote URL.
+// The path must consist of exactly two segments: owner and repository.
+func (p *GitHubProvider) ParseRemoteURL(remoteURL string) (RepoIdentity, error) {
+	u, err := ParseRemoteURL(remoteURL)
+	if err == nil && u.Host == p.host {
+		if segments := u.PathSegments(); len(segments) == 2 {
+			return RepoIdentity{Owner: segments[0], Repo: segments[1]}, nil
+		}
 	}
 
-	return "", "", fmt.Errorf("not a valid GitHub URL: %s", remoteURL)
+	return RepoIdentity{}, fmt.Errorf("not a valid GitHub URL for %s: %s", p.host, RedactRemoteURL(remoteURL))
 }
 
-// BuildGitHubFileURL constructs a GitHub blob URL for a specific file and line number.
-func BuildGitHubFileURL(owner, repo, branch, relPath string, lineNum int) string {
+// BuildFileURL constructs a GitHub blob URL for a single line.
+func (p *GitHubProvider) BuildFileURL(id RepoIdentity, ref, relPath string, lineNum int) string {
 	// Ensure forward slashes in path (cross-platform compatibility)
 	relPath = filepath.ToSlash(relPath)
 
-	// Construct URL: https://github.com/{owner}/{repo}/blob/{branch}/{path}#L{line}
+	// Construct URL: {baseURL}/{owner}/{repo}/blob/{branch}/{path}#L{line}
 	// Note: GitHub handles special characters in paths without URL encoding
-	return fmt.Sprintf("https://github.com/%s/%s/blob/%s/%s#L%d",
-		owner, repo, branch, relPath, lineNum)
+	return fmt.Sprintf("%s/%s/%s/blob/%s/%s#L%d",
+		p.baseURL, id.Owner, id.Repo, ref, relPath, lineNum)
+}
+
+// BuildLineRangeURL constructs a GitHub blob URL for a range of lines.
+func (p *GitHubProvider) BuildLineRangeURL(id RepoIdentity, ref, relPath string, startLine, endLine int) string {
+	// Construct URL: {baseURL}/{owner}/{repo}/blob/{branch}/{path}#L{start}-L{end}
+	return fmt.Sprintf("%s/%s/%s/blob/%s/%s#L%d-L%d",
+		p.baseURL, id.Owner, id.Repo, ref, filepath.ToSlash(relPath), startLine, endLine)
+}
+
+// BuildCommitURL constructs a GitHub commit URL.
+func (p *GitHubProvider) BuildCommitURL(id RepoIdentity, commit string) string {
+	// Construct URL: {baseURL}/{owner}/{repo}/commit/{sha}
+	return fmt.Sprintf("%s/%s/%s/commit/%s", p.baseURL, id.Owner, id.Repo, commit)
 }
@@ -0,0 +1,76 @@
+package git
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// gitLabHost is the host name of gitlab.com
+	gitLabHost = "gitlab.com"
+)
+
+// defaultGitLab is the provider for gitlab.com
+var defaultGitLab = NewGitLabProvider(gitLabHost, "")
+
+// GitLabProvider is the Provider implementation for gitlab.com and self-managed GitLab.
+type GitLabProvider struct {
+	host    string
+	baseURL string
+}
+
+// NewGitLabProvider creates a GitLabProvider for the given host.
+// baseURL is the web base URL (e.g., "https://gitlab.example.com"); if empty, "https://<host>" is used.
+func NewGitLabProvider(host, baseURL string) *GitLabProvider {
+	if baseURL == "" {
+		baseURL = "https://" + host
+	}
+
+	return &GitLabProvider{
+		host:    strings.ToLower(host),
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Name returns the provider name.
+func (p *GitLabProvider) Name() string {
+	return "gitlab"
+}
+
+// ParseRemoteURL parses a GitLab remote URL.
+// The namespace may contain nested groups (e.g., group/subgroup/project).
+func (p *GitLabProvider) ParseRemoteURL(remoteURL string) (RepoIdentity, error) {
+	u, err := ParseRemoteURL(remoteURL)
+	if err == nil && u.Host == p.host {
+		if segments := u.PathSegments(); len(segments) >= 2 {
+			last := len(segments) - 1
+			return RepoIdentity{Owner: strings.Join(segments[:last], "/"), Repo: segments[last]}, nil
+		}
+	}
+
+	return RepoIdentity{}, fmt.Errorf("not a valid GitLab URL for %s: %s", p.host, RedactRemoteURL(remoteURL))
+}
+
+// BuildFileURL constructs a GitLab blob URL for a single line.
+func (p *GitLabProvider) BuildFileURL(id RepoIdentity, ref, relPath string, lineNum int) string {
+	// Ensure forward slashes in path (cross-platform compatibility)
+	relPath = filepath.ToSlash(relPath)
+
+	// Construct URL: {baseURL}/{namespace}/{project}/-/blob/{ref}/{path}#L{line}
+	return fmt.Sprintf("%s/%s/%s/-/blob/%s/%s#L%d",
+		p.baseURL, id.Owner, id.Repo, ref, relPath, lineNum)
+}
+
+// BuildLineRangeURL constructs a GitLab blob URL for a range of lines.
+func (p *GitLabProvider) BuildLineRangeURL(id RepoIdentity, ref, relPath string, startLine, endLine int) string {
+	// Construct URL: {baseURL}/{namespace}/{project}/-/blob/{ref}/{path}#L{start}-{end}
+	return fmt.Sprintf("%s/%s/%s/-/blob/%s/%s#L%d-%d",
+		p.baseURL, id.Owner, id.Repo, ref, filepath.ToSlash(relPath), startLine, endLine)
+}
+
+// BuildCommitURL constructs a GitLab commit URL.
+func (p *GitLabProvider) BuildCommitURL(id RepoIdentity, commit string) string {
+	// Construct URL: {baseURL}/{namespace}/{project}/-/commit/{sha}
+	return fmt.Sprintf("%s/%s/%s/-/commit/%s", p.baseURL, id.Owner, id.Repo, commit)
+}
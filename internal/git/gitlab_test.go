@@ -0,0 +1,127 @@
+package git
+
+import "testing"
+
+func TestGitLabProvider_ParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		remoteURL     string
+		wantNamespace string
+		wantProject   string
+		wantErr       bool
+	}{
+		{
+			name:          "HTTPS URL with .git",
+			remoteURL:     "https://gitlab.com/group/project.git",
+			wantNamespace: "group",
+			wantProject:   "project",
+			wantErr:       false,
+		},
+		{
+			name:          "HTTPS URL without .git",
+			remoteURL:     "https://gitlab.com/group/project",
+			wantNamespace: "group",
+			wantProject:   "project",
+			wantErr:       false,
+		},
+		{
+			name:          "HTTPS URL with nested groups",
+			remoteURL:     "https://gitlab.com/group/subgroup/project.git",
+			wantNamespace: "group/subgroup",
+			wantProject:   "project",
+			wantErr:       false,
+		},
+		{
+			name:          "SSH URL with .git",
+			remoteURL:     "git@gitlab.com:group/project.git",
+			wantNamespace: "group",
+			wantProject:   "project",
+			wantErr:       false,
+		},
+		{
+			name:          "SSH URL with nested groups",
+			remoteURL:     "git@gitlab.com:group/subgroup/subsubgroup/project.git",
+			wantNamespace: "group/subgroup/subsubgroup",
+			wantProject:   "project",
+			wantErr:       false,
+		},
+		{
+			name:          "Invalid URL - GitHub",
+			remoteURL:     "https://github.com/owner/repo.git",
+			wantNamespace: "",
+			wantProject:   "",
+			wantErr:       true,
+		},
+		{
+			name:          "Invalid URL - no namespace",
+			remoteURL:     "https://gitlab.com/project.git",
+			wantNamespace: "",
+			wantProject:   "",
+			wantErr:       true,
+		},
+	}
+
+	provider := NewGitLabProvider("gitlab.com", "")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.ParseRemoteURL(tt.remoteURL)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRemoteURL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got.Owner != tt.wantNamespace {
+				t.Errorf("ParseRemoteURL() namespace = %v, want %v", got.Owner, tt.wantNamespace)
+			}
+
+			if got.Repo != tt.wantProject {
+				t.Errorf("ParseRemoteURL() project = %v, want %v", got.Repo, tt.wantProject)
+			}
+		})
+	}
+}
+
+func TestGitLabProvider_BuildFileURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		project   string
+		ref       string
+		relPath   string
+		lineNum   int
+		wantURL   string
+	}{
+		{
+			name:      "Simple file path",
+			namespace: "group",
+			project:   "project",
+			ref:       "main",
+			relPath:   "main.go",
+			lineNum:   10,
+			wantURL:   "https://gitlab.com/group/project/-/blob/main/main.go#L10",
+		},
+		{
+			name:      "Nested groups and file path",
+			namespace: "group/subgroup",
+			project:   "project",
+			ref:       "develop",
+			relPath:   "internal/git/remote.go",
+			lineNum:   42,
+			wantURL:   "https://gitlab.com/group/subgroup/project/-/blob/develop/internal/git/remote.go#L42",
+		},
+	}
+
+	provider := NewGitLabProvider("gitlab.com", "")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotURL := provider.BuildFileURL(RepoIdentity{Owner: tt.namespace, Repo: tt.project}, tt.ref, tt.relPath, tt.lineNum)
+
+			if gotURL != tt.wantURL {
+				t.Errorf("BuildFileURL() = %v, want %v", gotURL, tt.wantURL)
+			}
+		})
+	}
+}
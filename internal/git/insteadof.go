@@ -0,0 +1,118 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// RewriteRules holds url.<base>.insteadOf and url.<base>.pushInsteadOf rules from git config.
+// Each map is keyed by the alias prefix and holds the replacement base URL.
+type RewriteRules struct {
+	InsteadOf     map[string]string
+	PushInsteadOf map[string]string
+}
+
+// GetRewriteRules reads the url.<base>.insteadOf and url.<base>.pushInsteadOf rules
+// visible from the repository (local, global and system config).
+func GetRewriteRules(repoRoot string) (*RewriteRules, error) {
+	rules := &RewriteRules{
+		InsteadOf:     make(map[string]string),
+		PushInsteadOf: make(map[string]string),
+	}
+
+	// Execute: git -C <repoRoot> config -z --get-regexp ^url\..*insteadof$
+	// Each entry is output as "<key>\n<value>\0"
+	cmd := exec.Command("git", "-C", repoRoot, "config", "-z", "--get-regexp", `^url\..*insteadof$`)
+	output, err := cmd.Output()
+	if err != nil {
+		// Exit code 1 means no matching keys, which is not an error
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return rules, nil
+		}
+		return nil, fmt.Errorf("failed to read url rewrite rules: %w", err)
+	}
+
+	for _, entry := range strings.Split(string(output), "\x00") {
+		key, alias, found := strings.Cut(entry, "\n")
+		if !found {
+			continue
+		}
+
+		// Key format: url.<base>.insteadof or url.<base>.pushinsteadof
+		// (section and variable names are lowercased by git, <base> is kept as is)
+		base := strings.TrimPrefix(key, "url.")
+		switch {
+		case strings.HasSuffix(base, ".pushinsteadof"):
+			rules.PushInsteadOf[alias] = strings.TrimSuffix(base, ".pushinsteadof")
+		case strings.HasSuffix(base, ".insteadof"):
+			rules.InsteadOf[alias] = strings.TrimSuffix(base, ".insteadof")
+		}
+	}
+
+	return rules, nil
+}
+
+// applyRewrite replaces the longest matching alias prefix in rawURL, as git does.
+// It returns false if no alias matches.
+func applyRewrite(rawURL string, rewrites map[string]string) (string, bool) {
+	longest := ""
+	for alias := range rewrites {
+		if strings.HasPrefix(rawURL, alias) && len(alias) > len(longest) {
+			longest = alias
+		}
+	}
+
+	if longest == "" {
+		return rawURL, false
+	}
+	return rewrites[longest] + strings.TrimPrefix(rawURL, longest), true
+}
+
+// FetchURL returns the URL git fetches from after applying insteadOf rules.
+func (r *RewriteRules) FetchURL(rawURL string) string {
+	rewritten, _ := applyRewrite(rawURL, r.InsteadOf)
+	return rewritten
+}
+
+// PushURL returns the URL git pushes to after applying pushInsteadOf rules.
+// As in git, insteadOf rules are applied when no pushInsteadOf rule matches.
+func (r *RewriteRules) PushURL(rawURL string) string {
+	if rewritten, ok := applyRewrite(rawURL, r.PushInsteadOf); ok {
+		return rewritten
+	}
+	return r.FetchURL(rawURL)
+}
+
+// ResolveRemoteURLs returns the candidate URLs of the named remote after applying
+// url.<base>.insteadOf and url.<base>.pushInsteadOf rewrites.
+// The fetch URL comes first, followed by the push URL if it differs.
+func ResolveRemoteURLs(repoRoot, remote string) ([]string, error) {
+	// Execute: git -C <repoRoot> config --get remote.<remote>.url
+	cmd := exec.Command("git", "-C", repoRoot, "config", "--get", "remote."+remote+".url")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remote URL: remote %q not found", remote)
+	}
+	rawURL := strings.TrimSpace(string(output))
+
+	rules, err := GetRewriteRules(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := []string{rules.FetchURL(rawURL)}
+
+	// An explicit pushurl is used as is (git does not apply pushInsteadOf to it)
+	pushURL := rules.PushURL(rawURL)
+	cmd = exec.Command("git", "-C", repoRoot, "config", "--get", "remote."+remote+".pushurl")
+	if output, err := cmd.Output(); err == nil {
+		pushURL = rules.FetchURL(strings.TrimSpace(string(output)))
+	}
+
+	if pushURL != urls[0] {
+		urls = append(urls, pushURL)
+	}
+
+	return urls, nil
+}
@@ -0,0 +1,135 @@
+package git
+
+import (
+	"os/exec"
+	"testing"
+)
+
+// initRepoWithRemote initializes a Git repository with the given origin remote URL
+func initRepoWithRemote(t *testing.T, remoteURL string) string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	if err := exec.Command("git", "-C", tmpDir, "init").Run(); err != nil {
+		t.Fatalf("Failed to init git repo: %v", err)
+	}
+	if err := exec.Command("git", "-C", tmpDir, "remote", "add", "origin", remoteURL).Run(); err != nil {
+		t.Fatalf("Failed to add remote: %v", err)
+	}
+	return tmpDir
+}
+
+// setGitConfig sets a local git config value in the repository
+func setGitConfig(t *testing.T, repoDir, key, value string) {
+	t.Helper()
+
+	if err := exec.Command("git", "-C", repoDir, "config", key, value).Run(); err != nil {
+		t.Fatalf("Failed to set git config %s: %v", key, err)
+	}
+}
+
+func TestResolveRemoteURLs_NoRewrite(t *testing.T) {
+	tmpDir := initRepoWithRemote(t, "https://github.com/owner/repo.git")
+
+	urls, err := ResolveRemoteURLs(tmpDir, "origin")
+	if err != nil {
+		t.Fatalf("ResolveRemoteURLs() error = %v, want nil", err)
+	}
+
+	if len(urls) != 1 || urls[0] != "https://github.com/owner/repo.git" {
+		t.Errorf("ResolveRemoteURLs() = %v, want [https://github.com/owner/repo.git]", urls)
+	}
+}
+
+func TestResolveRemoteURLs_InsteadOf(t *testing.T) {
+	tmpDir := initRepoWithRemote(t, "gh:owner/repo")
+	setGitConfig(t, tmpDir, "url.https://github.com/.insteadOf", "gh:")
+
+	urls, err := ResolveRemoteURLs(tmpDir, "origin")
+	if err != nil {
+		t.Fatalf("ResolveRemoteURLs() error = %v, want nil", err)
+	}
+
+	if len(urls) != 1 || urls[0] != "https://github.com/owner/repo" {
+		t.Errorf("ResolveRemoteURLs() = %v, want [https://github.com/owner/repo]", urls)
+	}
+}
+
+func TestResolveRemoteURLs_LongestInsteadOfWins(t *testing.T) {
+	tmpDir := initRepoWithRemote(t, "gh:work/repo")
+	setGitConfig(t, tmpDir, "url.https://github.com/.insteadOf", "gh:")
+	setGitConfig(t, tmpDir, "url.git@ghe.corp.example:.insteadOf", "gh:work/")
+
+	urls, err := ResolveRemoteURLs(tmpDir, "origin")
+	if err != nil {
+		t.Fatalf("ResolveRemoteURLs() error = %v, want nil", err)
+	}
+
+	if len(urls) != 1 || urls[0] != "git@ghe.corp.example:repo" {
+		t.Errorf("ResolveRemoteURLs() = %v, want [git@ghe.corp.example:repo]", urls)
+	}
+}
+
+func TestResolveRemoteURLs_PushInsteadOf(t *testing.T) {
+	tmpDir := initRepoWithRemote(t, "priv:owner/repo.git")
+	setGitConfig(t, tmpDir, "url.git@github.com:.pushInsteadOf", "priv:")
+
+	urls, err := ResolveRemoteURLs(tmpDir, "origin")
+	if err != nil {
+		t.Fatalf("ResolveRemoteURLs() error = %v, want nil", err)
+	}
+
+	want := []string{"priv:owner/repo.git", "git@github.com:owner/repo.git"}
+	if len(urls) != len(want) || urls[0] != want[0] || urls[1] != want[1] {
+		t.Errorf("ResolveRemoteURLs() = %v, want %v", urls, want)
+	}
+}
+
+func TestResolveRemoteURLs_PushURL(t *testing.T) {
+	tmpDir := initRepoWithRemote(t, "/srv/git/repo.git")
+	setGitConfig(t, tmpDir, "remote.origin.pushurl", "gh:owner/repo")
+	setGitConfig(t, tmpDir, "url.https://github.com/.insteadOf", "gh:")
+
+	urls, err := ResolveRemoteURLs(tmpDir, "origin")
+	if err != nil {
+		t.Fatalf("ResolveRemoteURLs() error = %v, want nil", err)
+	}
+
+	want := []string{"/srv/git/repo.git", "https://github.com/owner/repo"}
+	if len(urls) != len(want) || urls[0] != want[0] || urls[1] != want[1] {
+		t.Errorf("ResolveRemoteURLs() = %v, want %v", urls, want)
+	}
+}
+
+func TestResolveRemoteURLs_NoRemote(t *testing.T) {
+	tmpDir := initRepoWithRemote(t, "https://github.com/owner/repo.git")
+
+	_, err := ResolveRemoteURLs(tmpDir, "upstream")
+	if err == nil {
+		t.Error("ResolveRemoteURLs() expected error for nonexistent remote, got nil")
+	}
+}
+
+func TestRewriteRules(t *testing.T) {
+	rules := &RewriteRules{
+		InsteadOf:     map[string]string{"gh:": "https://github.com/"},
+		PushInsteadOf: map[string]string{"https://github.com/": "git@github.com:"},
+	}
+
+	if got := rules.FetchURL("gh:owner/repo"); got != "https://github.com/owner/repo" {
+		t.Errorf("FetchURL() = %v, want https://github.com/owner/repo", got)
+	}
+
+	if got := rules.PushURL("https://github.com/owner/repo"); got != "git@github.com:owner/repo" {
+		t.Errorf("PushURL() = %v, want git@github.com:owner/repo", got)
+	}
+
+	// Falls back to insteadOf when no pushInsteadOf rule matches
+	if got := rules.PushURL("gh:owner/repo"); got != "https://github.com/owner/repo" {
+		t.Errorf("PushURL() = %v, want https://github.com/owner/repo", got)
+	}
+
+	if got := rules.FetchURL("https://gitlab.com/group/project"); got != "https://gitlab.com/group/project" {
+		t.Errorf("FetchURL() = %v, want unchanged URL", got)
+	}
+}
@@ -0,0 +1,89 @@
+package git
+
+import "fmt"
+
+// RepoIdentity identifies a repository on a hosting provider.
+type RepoIdentity struct {
+	Owner string // Owner or namespace (may contain nested groups, e.g., "group/subgroup")
+	Repo  string // Repository name
+}
+
+// FullName returns the repository identifier in "owner/repo" format.
+func (id RepoIdentity) FullName() string {
+	return fmt.Sprintf("%s/%s", id.Owner, id.Repo)
+}
+
+// Provider generates web URLs for repositories hosted on a specific service.
+type Provider interface {
+	// Name returns the provider name (e.g., "github").
+	Name() string
+
+	// ParseRemoteURL parses a remote URL and returns the repository identity.
+	// It returns an error if the URL does not belong to this provider.
+	ParseRemoteURL(remoteURL string) (RepoIdentity, error)
+
+	// BuildFileURL constructs a URL for a single line of a file at the given ref.
+	BuildFileURL(id RepoIdentity, ref, relPath string, lineNum int) string
+
+	// BuildLineRangeURL constructs a URL for a range of lines of a file at the given ref.
+	BuildLineRangeURL(id RepoIdentity, ref, relPath string, startLine, endLine int) string
+
+	// BuildCommitURL constructs a URL for a commit.
+	BuildCommitURL(id RepoIdentity, commit string) string
+}
+
+// Registry holds the providers consulted when resolving a remote URL.
+type Registry struct {
+	providers []Provider
+}
+
+// NewRegistry creates a new Registry with the given providers.
+// Providers are consulted in the order they are registered.
+func NewRegistry(providers ...Provider) *Registry {
+	return &Registry{
+		providers: providers,
+	}
+}
+
+// DefaultRegistry creates a Registry with all built-in providers.
+func DefaultRegistry() *Registry {
+	return NewRegistry(
+		defaultGitHub,
+		defaultGitLab,
+	)
+}
+
+// NewProvider creates a provider of the given type ("github" or "gitlab") for a custom host.
+// baseURL is the web base URL; if empty, "https://<host>" is used.
+func NewProvider(providerType, host, baseURL string) (Provider, error) {
+	if host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+
+	switch providerType {
+	case "github":
+		return NewGitHubProvider(host, baseURL), nil
+	case "gitlab":
+		return NewGitLabProvider(host, baseURL), nil
+	default:
+		return nil, fmt.Errorf("unknown provider type: %s", providerType)
+	}
+}
+
+// Register adds a provider to the registry.
+func (r *Registry) Register(p Provider) {
+	r.providers = append(r.providers, p)
+}
+
+// Resolve finds the provider that can parse the given remote URL
+// and returns it together with the parsed repository identity.
+func (r *Registry) Resolve(remoteURL string) (Provider, RepoIdentity, error) {
+	for _, p := range r.providers {
+		id, err := p.ParseRemoteURL(remoteURL)
+		if err == nil {
+			return p, id, nil
+		}
+	}
+
+	return nil, RepoIdentity{}, fmt.Errorf("unsupported remote URL: %s", RedactRemoteURL(remoteURL))
+}
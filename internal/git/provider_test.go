@@ -0,0 +1,260 @@
+package git
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRepoIdentity_FullName(t *testing.T) {
+	id := RepoIdentity{Owner: "group/subgroup", Repo: "project"}
+
+	if got := id.FullName(); got != "group/subgroup/project" {
+		t.Errorf("FullName() = %v, want %v", got, "group/subgroup/project")
+	}
+}
+
+func TestRegistry_Resolve(t *testing.T) {
+	tests := []struct {
+		name         string
+		remoteURL    string
+		wantProvider string
+		wantIdentity RepoIdentity
+		wantErr      bool
+	}{
+		{
+			name:         "GitHub HTTPS URL",
+			remoteURL:    "https://github.com/onozaty/reporg.git",
+			wantProvider: "github",
+			wantIdentity: RepoIdentity{Owner: "onozaty", Repo: "reporg"},
+			wantErr:      false,
+		},
+		{
+			name:         "GitHub SSH URL",
+			remoteURL:    "git@github.com:onozaty/reporg.git",
+			wantProvider: "github",
+			wantIdentity: RepoIdentity{Owner: "onozaty", Repo: "reporg"},
+			wantErr:      false,
+		},
+		{
+			name:         "GitLab URL with nested groups",
+			remoteURL:    "https://gitlab.com/group/subgroup/project.git",
+			wantProvider: "gitlab",
+			wantIdentity: RepoIdentity{Owner: "group/subgroup", Repo: "project"},
+			wantErr:      false,
+		},
+		{
+			name:      "Unsupported host",
+			remoteURL: "https://bitbucket.org/owner/repo.git",
+			wantErr:   true,
+		},
+	}
+
+	registry := DefaultRegistry()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, identity, err := registry.Resolve(tt.remoteURL)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if provider.Name() != tt.wantProvider {
+				t.Errorf("Resolve() provider = %v, want %v", provider.Name(), tt.wantProvider)
+			}
+
+			if identity != tt.wantIdentity {
+				t.Errorf("Resolve() identity = %v, want %v", identity, tt.wantIdentity)
+			}
+		})
+	}
+}
+
+// staticProvider is a test Provider that accepts a single remote URL
+type staticProvider struct {
+	remoteURL string
+}
+
+func (staticProvider) Name() string {
+	return "static"
+}
+
+func (p staticProvider) ParseRemoteURL(remoteURL string) (RepoIdentity, error) {
+	if remoteURL != p.remoteURL {
+		return RepoIdentity{}, fmt.Errorf("unsupported remote URL: %s", remoteURL)
+	}
+	return RepoIdentity{Owner: "static", Repo: "repo"}, nil
+}
+
+func (staticProvider) BuildFileURL(id RepoIdentity, ref, relPath string, lineNum int) string {
+	return ""
+}
+
+func (staticProvider) BuildLineRangeURL(id RepoIdentity, ref, relPath string, startLine, endLine int) string {
+	return ""
+}
+
+func (staticProvider) BuildCommitURL(id RepoIdentity, commit string) string {
+	return ""
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry := DefaultRegistry()
+
+	// Not resolvable before registering
+	if _, _, err := registry.Resolve("https://example.com/repo.git"); err == nil {
+		t.Fatal("Resolve() expected error before registering provider, got nil")
+	}
+
+	registry.Register(staticProvider{remoteURL: "https://example.com/repo.git"})
+
+	provider, identity, err := registry.Resolve("https://example.com/repo.git")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v, want nil", err)
+	}
+
+	if provider.Name() != "static" {
+		t.Errorf("Resolve() provider = %v, want static", provider.Name())
+	}
+
+	if identity.FullName() != "static/repo" {
+		t.Errorf("Resolve() identity = %v, want static/repo", identity.FullName())
+	}
+}
+
+func TestProvider_BuildURLs(t *testing.T) {
+	tests := []struct {
+		name          string
+		provider      Provider
+		identity      RepoIdentity
+		wantFileURL   string
+		wantRangeURL  string
+		wantCommitURL string
+	}{
+		{
+			name:          "GitHub",
+			provider:      NewGitHubProvider("github.com", ""),
+			identity:      RepoIdentity{Owner: "onozaty", Repo: "reporg"},
+			wantFileURL:   "https://github.com/onozaty/reporg/blob/main/internal/git/repo.go#L10",
+			wantRangeURL:  "https://github.com/onozaty/reporg/blob/main/internal/git/repo.go#L10-L14",
+			wantCommitURL: "https://github.com/onozaty/reporg/commit/0123abc",
+		},
+		{
+			name:          "GitLab",
+			provider:      NewGitLabProvider("gitlab.com", ""),
+			identity:      RepoIdentity{Owner: "group/subgroup", Repo: "project"},
+			wantFileURL:   "https://gitlab.com/group/subgroup/project/-/blob/main/internal/git/repo.go#L10",
+			wantRangeURL:  "https://gitlab.com/group/subgroup/project/-/blob/main/internal/git/repo.go#L10-14",
+			wantCommitURL: "https://gitlab.com/group/subgroup/project/-/commit/0123abc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotFileURL := tt.provider.BuildFileURL(tt.identity, "main", "internal/git/repo.go", 10)
+			if gotFileURL != tt.wantFileURL {
+				t.Errorf("BuildFileURL() = %v, want %v", gotFileURL, tt.wantFileURL)
+			}
+
+			gotRangeURL := tt.provider.BuildLineRangeURL(tt.identity, "main", "internal/git/repo.go", 10, 14)
+			if gotRangeURL != tt.wantRangeURL {
+				t.Errorf("BuildLineRangeURL() = %v, want %v", gotRangeURL, tt.wantRangeURL)
+			}
+
+			gotCommitURL := tt.provider.BuildCommitURL(tt.identity, "0123abc")
+			if gotCommitURL != tt.wantCommitURL {
+				t.Errorf("BuildCommitURL() = %v, want %v", gotCommitURL, tt.wantCommitURL)
+			}
+		})
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name         string
+		providerType string
+		host         string
+		baseURL      string
+		remoteURL    string
+		wantIdentity RepoIdentity
+		wantFileURL  string
+		wantErr      bool
+	}{
+		{
+			name:         "GitHub Enterprise SSH",
+			providerType: "github",
+			host:         "ghe.corp.example",
+			baseURL:      "",
+			remoteURL:    "git@ghe.corp.example:team/svc.git",
+			wantIdentity: RepoIdentity{Owner: "team", Repo: "svc"},
+			wantFileURL:  "https://ghe.corp.example/team/svc/blob/main/main.go#L3",
+		},
+		{
+			name:         "GitHub Enterprise with custom base URL",
+			providerType: "github",
+			host:         "ghe.corp.example",
+			baseURL:      "https://web.corp.example/github/",
+			remoteURL:    "https://ghe.corp.example/team/svc.git",
+			wantIdentity: RepoIdentity{Owner: "team", Repo: "svc"},
+			wantFileURL:  "https://web.corp.example/github/team/svc/blob/main/main.go#L3",
+		},
+		{
+			name:         "Self-managed GitLab",
+			providerType: "gitlab",
+			host:         "git.corp.example",
+			baseURL:      "",
+			remoteURL:    "git@git.corp.example:group/subgroup/project.git",
+			wantIdentity: RepoIdentity{Owner: "group/subgroup", Repo: "project"},
+			wantFileURL:  "https://git.corp.example/group/subgroup/project/-/blob/main/main.go#L3",
+		},
+		{
+			name:         "Unknown provider type",
+			providerType: "unknown",
+			host:         "git.corp.example",
+			wantErr:      true,
+		},
+		{
+			name:         "Empty host",
+			providerType: "github",
+			host:         "",
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewProvider(tt.providerType, tt.host, tt.baseURL)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewProvider() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			identity, err := provider.ParseRemoteURL(tt.remoteURL)
+			if err != nil {
+				t.Fatalf("ParseRemoteURL() error = %v, want nil", err)
+			}
+
+			if identity != tt.wantIdentity {
+				t.Errorf("ParseRemoteURL() identity = %v, want %v", identity, tt.wantIdentity)
+			}
+
+			gotFileURL := provider.BuildFileURL(identity, "main", "main.go", 3)
+			if gotFileURL != tt.wantFileURL {
+				t.Errorf("BuildFileURL() = %v, want %v", gotFileURL, tt.wantFileURL)
+			}
+
+			// github.com remotes must not be accepted by a custom host provider
+			if _, err := provider.ParseRemoteURL("https://github.com/owner/repo.git"); err == nil {
+				t.Error("ParseRemoteURL() expected error for other host, got nil")
+			}
+		})
+	}
+}
@@ -41,6 +41,142 @@ func GetCurrentBranch(repoRoot string) (string, error) {
 	return branch, nil
 }
 
+// ListRemotes returns the names of the remotes configured in the repository.
+func ListRemotes(repoRoot string) ([]string, error) {
+	// Execute: git -C <repoRoot> remote
+	cmd := exec.Command("git", "-C", repoRoot, "remote")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remotes: %w", err)
+	}
+
+	return strings.Fields(string(output)), nil
+}
+
+// GetUpstream returns the remote name and the branch name on that remote
+// of the upstream configured for the current branch (e.g., "origin" and "team/feature-x").
+// Returns empty strings if HEAD is detached or the branch has no upstream on a remote.
+func GetUpstream(repoRoot string) (remote, branch string, err error) {
+	localBranch, err := GetCurrentBranch(repoRoot)
+	if err != nil || localBranch == "" {
+		return "", "", err
+	}
+
+	// Execute: git -C <repoRoot> config --get branch.<branch>.remote
+	cmd := exec.Command("git", "-C", repoRoot, "config", "--get", "branch."+localBranch+".remote")
+	output, err := cmd.Output()
+	if err != nil {
+		// No upstream configured
+		return "", "", nil
+	}
+
+	remote = strings.TrimSpace(string(output))
+	if remote == "." {
+		// Upstream is a local branch
+		return "", "", nil
+	}
+
+	// Execute: git -C <repoRoot> config --get branch.<branch>.merge
+	// This returns the ref on the remote (e.g., "refs/heads/team/feature-x")
+	cmd = exec.Command("git", "-C", repoRoot, "config", "--get", "branch."+localBranch+".merge")
+	output, err = cmd.Output()
+	if err != nil {
+		return remote, "", nil
+	}
+
+	branch = strings.TrimPrefix(strings.TrimSpace(string(output)), "refs/heads/")
+	return remote, branch, nil
+}
+
+// GetTrackingRemote returns the name of the remote tracked by the current branch.
+// Returns an empty string if HEAD is detached or the branch has no upstream on a remote.
+func GetTrackingRemote(repoRoot string) (string, error) {
+	remote, _, err := GetUpstream(repoRoot)
+	return remote, err
+}
+
+// GetDefaultBranch returns the default branch of the given remote.
+// It first reads refs/remotes/<remote>/HEAD. If that is not set and the remote is a
+// local repository, the remote HEAD is queried with git ls-remote --symref.
+// Remote repositories on other hosts are never contacted.
+func GetDefaultBranch(repoRoot, remote string) (string, error) {
+	// Execute: git -C <repoRoot> symbolic-ref --short refs/remotes/<remote>/HEAD
+	// This returns "<remote>/<branch>"
+	cmd := exec.Command("git", "-C", repoRoot, "symbolic-ref", "--short", "refs/remotes/"+remote+"/HEAD")
+	if output, err := cmd.Output(); err == nil {
+		branch := strings.TrimSpace(string(output))
+		return strings.TrimPrefix(branch, remote+"/"), nil
+	}
+
+	// Fall back to ls-remote only for local remotes
+	remoteURLs, err := ResolveRemoteURLs(repoRoot, remote)
+	if err != nil {
+		return "", err
+	}
+	u, err := ParseRemoteURL(remoteURLs[0])
+	if err != nil || !u.IsLocal() {
+		return "", fmt.Errorf("default branch of remote %s is unknown", remote)
+	}
+
+	// Execute: git -C <repoRoot> ls-remote --symref <remote> HEAD
+	// The first line is "ref: refs/heads/<branch>\tHEAD"
+	cmd = exec.Command("git", "-C", repoRoot, "ls-remote", "--symref", remote, "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to query remote HEAD: %w", err)
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		if ref, found := strings.CutPrefix(line, "ref: refs/heads/"); found {
+			branch, _, _ := strings.Cut(ref, "\t")
+			return branch, nil
+		}
+	}
+
+	return "", fmt.Errorf("default branch of remote %s is unknown", remote)
+}
+
+// GetHeadCommit returns the full commit SHA that HEAD points to.
+func GetHeadCommit(repoRoot string) (string, error) {
+	// Execute: git -C <repoRoot> rev-parse HEAD
+	cmd := exec.Command("git", "-C", repoRoot, "rev-parse", "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get HEAD commit: %w", err)
+	}
+
+	commit := strings.TrimSpace(string(output))
+	return commit, nil
+}
+
+// ListTrackedFiles returns the files tracked by git (slash-separated, relative to the repository root).
+// Files outside a sparse checkout (skip-worktree entries) are excluded, as they are not in the working tree.
+func ListTrackedFiles(repoRoot string) ([]string, error) {
+	// Execute: git -C <repoRoot> ls-files -z -t
+	// Each entry is "<tag> <path>\0", where the tag is "S" (or "s") for skip-worktree entries
+	cmd := exec.Command("git", "-C", repoRoot, "ls-files", "-z", "-t")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracked files: %w", err)
+	}
+
+	files := []string{}
+	for _, entry := range strings.Split(string(output), "\x00") {
+		tag, path, found := strings.Cut(entry, " ")
+		if !found || strings.EqualFold(tag, "S") {
+			continue
+		}
+		// An unmerged file is listed once per stage, in consecutive entries
+		// (--deduplicate has no effect with -t)
+		if len(files) > 0 && files[len(files)-1] == path {
+			continue
+		}
+		files = append(files, path)
+	}
+
+	return files, nil
+}
+
 // DeduplicateRepoPaths takes a list of repository paths and returns unique repository roots.
 // It validates each path and removes duplicates based on canonical paths.
 func DeduplicateRepoPaths(paths []string) ([]string, error) {
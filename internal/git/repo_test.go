@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -196,3 +197,245 @@ func TestDeduplicateRepoPaths_MixedValidInvalid(t *testing.T) {
 		t.Error("DeduplicateRepoPaths() expected error for invalid repository, got nil")
 	}
 }
+
+func TestGetHeadCommit(t *testing.T) {
+	// Create temporary directory for Git repository
+	tmpDir := t.TempDir()
+
+	// Initialize Git repository with initial commit
+	initTestRepo(t, tmpDir)
+
+	// Test GetHeadCommit
+	commit, err := GetHeadCommit(tmpDir)
+	if err != nil {
+		t.Fatalf("GetHeadCommit() error = %v, want nil", err)
+	}
+
+	// Should be a full 40-character SHA
+	if len(commit) != 40 {
+		t.Errorf("GetHeadCommit() = %v, want 40-character SHA", commit)
+	}
+}
+
+func TestGetHeadCommit_NoCommits(t *testing.T) {
+	// Create temporary directory for Git repository without commits
+	tmpDir := t.TempDir()
+	exec.Command("git", "-C", tmpDir, "init").Run()
+
+	// Test GetHeadCommit - should fail
+	_, err := GetHeadCommit(tmpDir)
+	if err == nil {
+		t.Error("GetHeadCommit() expected error for repository without commits, got nil")
+	}
+}
+
+func TestListRemotes(t *testing.T) {
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+
+	exec.Command("git", "-C", tmpDir, "remote", "add", "origin", "https://github.com/fork/repo.git").Run()
+	exec.Command("git", "-C", tmpDir, "remote", "add", "upstream", "https://github.com/owner/repo.git").Run()
+
+	remotes, err := ListRemotes(tmpDir)
+	if err != nil {
+		t.Fatalf("ListRemotes() error = %v, want nil", err)
+	}
+
+	if len(remotes) != 2 || remotes[0] != "origin" || remotes[1] != "upstream" {
+		t.Errorf("ListRemotes() = %v, want [origin upstream]", remotes)
+	}
+}
+
+func TestGetTrackingRemote(t *testing.T) {
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+
+	// No upstream configured
+	remote, err := GetTrackingRemote(tmpDir)
+	if err != nil {
+		t.Fatalf("GetTrackingRemote() error = %v, want nil", err)
+	}
+	if remote != "" {
+		t.Errorf("GetTrackingRemote() = %v, want empty", remote)
+	}
+
+	// Configure upstream on the current branch
+	branch, _ := GetCurrentBranch(tmpDir)
+	exec.Command("git", "-C", tmpDir, "config", "branch."+branch+".remote", "upstream").Run()
+	exec.Command("git", "-C", tmpDir, "config", "branch."+branch+".merge", "refs/heads/"+branch).Run()
+
+	remote, err = GetTrackingRemote(tmpDir)
+	if err != nil {
+		t.Fatalf("GetTrackingRemote() error = %v, want nil", err)
+	}
+	if remote != "upstream" {
+		t.Errorf("GetTrackingRemote() = %v, want upstream", remote)
+	}
+}
+
+// initBareRemote creates a bare repository whose HEAD points to the given branch
+// and pushes an initial commit to it
+func initBareRemote(t *testing.T, branch string) string {
+	t.Helper()
+
+	bareDir := t.TempDir()
+	exec.Command("git", "init", "--bare", bareDir).Run()
+
+	workDir := t.TempDir()
+	initTestRepo(t, workDir)
+	exec.Command("git", "-C", workDir, "push", bareDir, "HEAD:refs/heads/"+branch).Run()
+	exec.Command("git", "-C", bareDir, "symbolic-ref", "HEAD", "refs/heads/"+branch).Run()
+
+	return bareDir
+}
+
+func TestGetDefaultBranch_RemoteHEAD(t *testing.T) {
+	bareDir := initBareRemote(t, "develop")
+
+	// Clone sets refs/remotes/origin/HEAD
+	cloneDir := filepath.Join(t.TempDir(), "clone")
+	if err := exec.Command("git", "clone", bareDir, cloneDir).Run(); err != nil {
+		t.Fatalf("Failed to clone: %v", err)
+	}
+
+	branch, err := GetDefaultBranch(cloneDir, "origin")
+	if err != nil {
+		t.Fatalf("GetDefaultBranch() error = %v, want nil", err)
+	}
+
+	if branch != "develop" {
+		t.Errorf("GetDefaultBranch() = %v, want develop", branch)
+	}
+}
+
+func TestGetDefaultBranch_LsRemote(t *testing.T) {
+	bareDir := initBareRemote(t, "master")
+
+	// Remote added without fetching, so refs/remotes/origin/HEAD does not exist
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+	exec.Command("git", "-C", tmpDir, "remote", "add", "origin", bareDir).Run()
+
+	branch, err := GetDefaultBranch(tmpDir, "origin")
+	if err != nil {
+		t.Fatalf("GetDefaultBranch() error = %v, want nil", err)
+	}
+
+	if branch != "master" {
+		t.Errorf("GetDefaultBranch() = %v, want master", branch)
+	}
+}
+
+func TestGetDefaultBranch_Unknown(t *testing.T) {
+	// Remote on another host is never contacted
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+	exec.Command("git", "-C", tmpDir, "remote", "add", "origin", "https://github.com/owner/repo.git").Run()
+
+	_, err := GetDefaultBranch(tmpDir, "origin")
+	if err == nil {
+		t.Error("GetDefaultBranch() expected error for unknown default branch, got nil")
+	}
+}
+
+func TestGetUpstream(t *testing.T) {
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+	exec.Command("git", "-C", tmpDir, "checkout", "-b", "feature-x").Run()
+
+	// No upstream configured
+	remote, branch, err := GetUpstream(tmpDir)
+	if err != nil {
+		t.Fatalf("GetUpstream() error = %v, want nil", err)
+	}
+	if remote != "" || branch != "" {
+		t.Errorf("GetUpstream() = %v, %v, want empty", remote, branch)
+	}
+
+	// Track a differently named branch on origin
+	exec.Command("git", "-C", tmpDir, "config", "branch.feature-x.remote", "origin").Run()
+	exec.Command("git", "-C", tmpDir, "config", "branch.feature-x.merge", "refs/heads/team/feature-x").Run()
+
+	remote, branch, err = GetUpstream(tmpDir)
+	if err != nil {
+		t.Fatalf("GetUpstream() error = %v, want nil", err)
+	}
+	if remote != "origin" {
+		t.Errorf("GetUpstream() remote = %v, want origin", remote)
+	}
+	if branch != "team/feature-x" {
+		t.Errorf("GetUpstream() branch = %v, want team/feature-x", branch)
+	}
+}
+
+func TestGetUpstream_LocalBranch(t *testing.T) {
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+
+	// Upstream is a local branch (branch.<name>.remote = ".")
+	exec.Command("git", "-C", tmpDir, "branch", "base").Run()
+	exec.Command("git", "-C", tmpDir, "checkout", "-b", "feature-x", "--track", "base").Run()
+
+	remote, branch, err := GetUpstream(tmpDir)
+	if err != nil {
+		t.Fatalf("GetUpstream() error = %v, want nil", err)
+	}
+	if remote != "" || branch != "" {
+		t.Errorf("GetUpstream() = %v, %v, want empty", remote, branch)
+	}
+}
+
+func TestListTrackedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+	commitTestFile(t, tmpDir, "src/main.go", "package main\n")
+	commitTestFile(t, tmpDir, "docs/guide.md", "guide\n")
+
+	// Untracked file
+	os.WriteFile(filepath.Join(tmpDir, "scratch.txt"), []byte("scratch\n"), 0644)
+	// Outside the sparse checkout
+	exec.Command("git", "-C", tmpDir, "update-index", "--skip-worktree", "docs/guide.md").Run()
+
+	files, err := ListTrackedFiles(tmpDir)
+	if err != nil {
+		t.Fatalf("ListTrackedFiles() error = %v, want nil", err)
+	}
+
+	want := []string{"README.md", "src/main.go"}
+	if len(files) != len(want) {
+		t.Fatalf("ListTrackedFiles() = %v, want %v", files, want)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Errorf("ListTrackedFiles()[%d] = %v, want %v", i, files[i], want[i])
+		}
+	}
+}
+
+func TestListTrackedFiles_Conflict(t *testing.T) {
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+	commitTestFile(t, tmpDir, "f.txt", "base\n")
+	branchOutput, _ := exec.Command("git", "-C", tmpDir, "branch", "--show-current").Output()
+	base := strings.TrimSpace(string(branchOutput))
+
+	exec.Command("git", "-C", tmpDir, "checkout", "-q", "-b", "other").Run()
+	commitTestFile(t, tmpDir, "f.txt", "other\n")
+	exec.Command("git", "-C", tmpDir, "checkout", "-q", base).Run()
+	commitTestFile(t, tmpDir, "f.txt", "ours\n")
+
+	// The merge leaves f.txt with three stages in the index
+	if err := exec.Command("git", "-C", tmpDir, "merge", "other").Run(); err == nil {
+		t.Fatal("Expected the merge to conflict")
+	}
+
+	files, err := ListTrackedFiles(tmpDir)
+	if err != nil {
+		t.Fatalf("ListTrackedFiles() error = %v, want nil", err)
+	}
+
+	want := []string{"README.md", "f.txt"}
+	if strings.Join(files, ",") != strings.Join(want, ",") {
+		t.Errorf("ListTrackedFiles() = %v, want %v", files, want)
+	}
+}
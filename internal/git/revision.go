@@ -0,0 +1,83 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveCommit returns the full commit SHA that rev (branch, tag, SHA, etc.) refers to.
+func ResolveCommit(repoRoot, rev string) (string, error) {
+	// Execute: git -C <repoRoot> rev-parse --verify --quiet <rev>^{commit}
+	cmd := exec.Command("git", "-C", repoRoot, "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("unknown revision: %s", rev)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// RevisionRefName returns the name to use in URLs for rev on the given remote.
+// Tags and branches are returned by name (without the remote prefix for
+// remote-tracking branches). Returns an empty string for any other revision.
+func RevisionRefName(repoRoot, remote, rev string) string {
+	switch {
+	case revParseVerify(repoRoot, "refs/tags/"+rev):
+		return rev
+	case revParseVerify(repoRoot, "refs/heads/"+rev):
+		return rev
+	case strings.HasPrefix(rev, remote+"/") && revParseVerify(repoRoot, "refs/remotes/"+rev):
+		return strings.TrimPrefix(rev, remote+"/")
+	default:
+		return ""
+	}
+}
+
+// ExportRevision writes the files of rev to destDir as a checkout would,
+// without touching the working tree or the index of the repository.
+func ExportRevision(repoRoot, rev, destDir string) error {
+	// Use a temporary index so that the repository's index is left untouched
+	indexDir, err := os.MkdirTemp("", "reporg-index-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(indexDir)
+
+	env := append(os.Environ(), "GIT_INDEX_FILE="+filepath.Join(indexDir, "index"))
+
+	// Execute: git -C <repoRoot> read-tree <rev>
+	cmd := exec.Command("git", "-C", repoRoot, "read-tree", rev)
+	cmd.Env = env
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to read tree of %s: %s", rev, strings.TrimSpace(string(output)))
+	}
+
+	absDest, err := filepath.Abs(destDir)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	// Execute: git -C <repoRoot> checkout-index --all --force --prefix=<destDir>/
+	cmd = exec.Command("git", "-C", repoRoot, "checkout-index", "--all", "--force", "--prefix="+filepath.ToSlash(absDest)+"/")
+	cmd.Env = env
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to export %s: %s", rev, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// ReadFileAtRevision returns the content of the file at relPath (slash-separated) in rev.
+func ReadFileAtRevision(repoRoot, rev, relPath string) ([]byte, error) {
+	// Execute: git -C <repoRoot> cat-file blob <rev>:<relPath>
+	cmd := exec.Command("git", "-C", repoRoot, "cat-file", "blob", rev+":"+relPath)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s at %s: %w", relPath, rev, err)
+	}
+
+	return output, nil
+}
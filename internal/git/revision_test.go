@@ -0,0 +1,135 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// commitTestFile writes a file and commits it to the repository
+func commitTestFile(t *testing.T, repoDir, relPath, content string) {
+	t.Helper()
+
+	filePath := filepath.Join(repoDir, relPath)
+	os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	exec.Command("git", "-C", repoDir, "add", relPath).Run()
+	exec.Command("git", "-C", repoDir, "commit", "-m", "Update "+relPath).Run()
+}
+
+func TestResolveCommit(t *testing.T) {
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+	exec.Command("git", "-C", tmpDir, "tag", "v1.0").Run()
+
+	head, _ := GetHeadCommit(tmpDir)
+
+	commit, err := ResolveCommit(tmpDir, "v1.0")
+	if err != nil {
+		t.Fatalf("ResolveCommit() error = %v, want nil", err)
+	}
+	if commit != head {
+		t.Errorf("ResolveCommit() = %v, want %v", commit, head)
+	}
+
+	if _, err := ResolveCommit(tmpDir, "nonexistent"); err == nil {
+		t.Error("ResolveCommit() expected error for unknown revision, got nil")
+	}
+}
+
+func TestRevisionRefName(t *testing.T) {
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+	exec.Command("git", "-C", tmpDir, "tag", "v1.0").Run()
+	exec.Command("git", "-C", tmpDir, "branch", "release/1.0").Run()
+	exec.Command("git", "-C", tmpDir, "update-ref", "refs/remotes/origin/release/2.0", "HEAD").Run()
+	head, _ := GetHeadCommit(tmpDir)
+
+	tests := []struct {
+		rev  string
+		want string
+	}{
+		{rev: "v1.0", want: "v1.0"},
+		{rev: "release/1.0", want: "release/1.0"},
+		{rev: "origin/release/2.0", want: "release/2.0"},
+		{rev: head, want: ""},
+		{rev: "HEAD~0", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rev, func(t *testing.T) {
+			if got := RevisionRefName(tmpDir, "origin", tt.rev); got != tt.want {
+				t.Errorf("RevisionRefName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportRevision(t *testing.T) {
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+	commitTestFile(t, tmpDir, "dir/file.txt", "old content\n")
+	exec.Command("git", "-C", tmpDir, "tag", "v1.0").Run()
+	commitTestFile(t, tmpDir, "dir/file.txt", "new content\n")
+	commitTestFile(t, tmpDir, "added.txt", "added\n")
+
+	destDir := t.TempDir()
+	if err := ExportRevision(tmpDir, "v1.0", destDir); err != nil {
+		t.Fatalf("ExportRevision() error = %v, want nil", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(destDir, "dir", "file.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read exported file: %v", err)
+	}
+	if string(content) != "old content\n" {
+		t.Errorf("Exported content = %q, want %q", string(content), "old content\n")
+	}
+
+	if _, err := os.Stat(filepath.Join(destDir, "added.txt")); !os.IsNotExist(err) {
+		t.Error("File added after the revision should not be exported")
+	}
+
+	// Working tree must be left untouched
+	content, _ = os.ReadFile(filepath.Join(tmpDir, "dir", "file.txt"))
+	if string(content) != "new content\n" {
+		t.Errorf("Working tree content = %q, want %q", string(content), "new content\n")
+	}
+
+	statusOutput, _ := exec.Command("git", "-C", tmpDir, "status", "--porcelain").Output()
+	if len(statusOutput) != 0 {
+		t.Errorf("Repository status should be clean, got: %s", string(statusOutput))
+	}
+}
+
+func TestExportRevision_UnknownRevision(t *testing.T) {
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+
+	if err := ExportRevision(tmpDir, "nonexistent", t.TempDir()); err == nil {
+		t.Error("ExportRevision() expected error for unknown revision, got nil")
+	}
+}
+
+func TestReadFileAtRevision(t *testing.T) {
+	tmpDir := t.TempDir()
+	initTestRepo(t, tmpDir)
+	commitTestFile(t, tmpDir, "dir/file.txt", "old content\n")
+	exec.Command("git", "-C", tmpDir, "tag", "v1.0").Run()
+	commitTestFile(t, tmpDir, "dir/file.txt", "new content\n")
+
+	content, err := ReadFileAtRevision(tmpDir, "v1.0", "dir/file.txt")
+	if err != nil {
+		t.Fatalf("ReadFileAtRevision() error = %v, want nil", err)
+	}
+	if string(content) != "old content\n" {
+		t.Errorf("ReadFileAtRevision() = %q, want %q", string(content), "old content\n")
+	}
+
+	if _, err := ReadFileAtRevision(tmpDir, "v1.0", "nonexistent.txt"); err == nil {
+		t.Error("ReadFileAtRevision() expected error for nonexistent file, got nil")
+	}
+}
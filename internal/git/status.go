@@ -0,0 +1,161 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// LinkStatus describes whether the local content of a file matches the revision its URL points to.
+type LinkStatus string
+
+const (
+	LinkClean     LinkStatus = "clean"     // Local file matches the linked revision
+	LinkModified  LinkStatus = "modified"  // File has uncommitted changes (staged or unstaged)
+	LinkUntracked LinkStatus = "untracked" // File is not tracked by git
+	LinkUnpushed  LinkStatus = "unpushed"  // Committed content differs from the linked revision on the remote
+)
+
+// LinkChecker determines the link status of files in a repository.
+type LinkChecker struct {
+	worktree    map[string]LinkStatus // Status of files with local changes (slash-separated paths)
+	unpushed    map[string]bool       // Files whose committed content differs from the remote revision
+	allUnpushed bool                  // The linked revision does not exist on the remote
+}
+
+// NewLinkChecker creates a LinkChecker for URLs pointing to ref on the given remote.
+// Local changes are computed from git status --porcelain, and unpushed changes from
+// git diff between the remote revision and HEAD.
+func NewLinkChecker(repoRoot, remote, ref string) (*LinkChecker, error) {
+	worktree, err := getWorktreeStatuses(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return newLinkChecker(repoRoot, remote, ref, "HEAD", worktree)
+}
+
+// NewRevisionLinkChecker creates a LinkChecker for files searched in rev instead of the working tree.
+// Local changes are ignored, and unpushed changes are computed from git diff between
+// the remote revision and rev.
+func NewRevisionLinkChecker(repoRoot, remote, ref, rev string) (*LinkChecker, error) {
+	return newLinkChecker(repoRoot, remote, ref, rev, map[string]LinkStatus{})
+}
+
+// newLinkChecker creates a LinkChecker comparing localRev with the revision of ref on the remote.
+func newLinkChecker(repoRoot, remote, ref, localRev string, worktree map[string]LinkStatus) (*LinkChecker, error) {
+	checker := &LinkChecker{
+		worktree: worktree,
+		unpushed: make(map[string]bool),
+	}
+
+	remoteRev := findRemoteRevision(repoRoot, remote, ref)
+	if remoteRev == "" {
+		// The linked revision is not on the remote, so every link is broken
+		checker.allUnpushed = true
+		return checker, nil
+	}
+
+	// Execute: git -C <repoRoot> diff --name-only -z <remoteRev> <localRev>
+	cmd := exec.Command("git", "-C", repoRoot, "diff", "--name-only", "-z", remoteRev, localRev)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff against %s: %w", remoteRev, err)
+	}
+
+	for _, path := range strings.Split(string(output), "\x00") {
+		if path != "" {
+			checker.unpushed[path] = true
+		}
+	}
+
+	return checker, nil
+}
+
+// Status returns the link status of the file at relPath (relative to the repository root).
+func (c *LinkChecker) Status(relPath string) LinkStatus {
+	relPath = filepath.ToSlash(relPath)
+
+	if status, ok := c.worktree[relPath]; ok {
+		return status
+	}
+	if c.allUnpushed || c.unpushed[relPath] {
+		return LinkUnpushed
+	}
+	return LinkClean
+}
+
+// getWorktreeStatuses returns the status of modified and untracked files from git status --porcelain.
+func getWorktreeStatuses(repoRoot string) (map[string]LinkStatus, error) {
+	// Execute: git -C <repoRoot> status --porcelain -z --untracked-files=all
+	// Each entry is "XY <path>\0", renames and copies are followed by "<orig path>\0"
+	cmd := exec.Command("git", "-C", repoRoot, "status", "--porcelain", "-z", "--untracked-files=all")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git status: %w", err)
+	}
+
+	statuses := make(map[string]LinkStatus)
+	entries := strings.Split(string(output), "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+
+		xy, path := entry[:2], entry[3:]
+		if xy == "??" {
+			statuses[path] = LinkUntracked
+		} else {
+			statuses[path] = LinkModified
+		}
+
+		// Skip the original path of renames and copies
+		if xy[0] == 'R' || xy[0] == 'C' {
+			i++
+		}
+	}
+
+	return statuses, nil
+}
+
+// findRemoteRevision returns the local name of the revision that ref refers to on the remote.
+// Returns an empty string if the revision is not known to exist on the remote.
+func findRemoteRevision(repoRoot, remote, ref string) string {
+	// Branch on the remote: use its remote-tracking branch
+	remoteBranch := "refs/remotes/" + remote + "/" + ref
+	if revParseVerify(repoRoot, remoteBranch) {
+		return remoteBranch
+	}
+
+	// Local branch that does not exist on the remote
+	if revParseVerify(repoRoot, "refs/heads/"+ref) {
+		return ""
+	}
+
+	// Tags are assumed to be pushed
+	if revParseVerify(repoRoot, "refs/tags/"+ref) {
+		return "refs/tags/" + ref
+	}
+
+	// Commit: must be reachable from a remote-tracking branch
+	if !revParseVerify(repoRoot, ref+"^{commit}") {
+		return ""
+	}
+
+	// Execute: git -C <repoRoot> for-each-ref --contains <ref> refs/remotes/<remote>
+	cmd := exec.Command("git", "-C", repoRoot, "for-each-ref", "--contains", ref, "--format=%(refname)", "refs/remotes/"+remote)
+	output, err := cmd.Output()
+	if err != nil || strings.TrimSpace(string(output)) == "" {
+		return ""
+	}
+	return ref
+}
+
+// revParseVerify reports whether rev resolves to an object in the repository.
+func revParseVerify(repoRoot, rev string) bool {
+	// Execute: git -C <repoRoot> rev-parse --verify --quiet <rev>
+	cmd := exec.Command("git", "-C", repoRoot, "rev-parse", "--verify", "--quiet", rev)
+	return cmd.Run() == nil
+}
@@ -0,0 +1,131 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// setupLinkTestRepo creates a clone of a bare repository so that the current branch
+// exists on the origin remote, and returns the clone directory and branch name
+func setupLinkTestRepo(t *testing.T) (string, string) {
+	t.Helper()
+
+	bareDir := initBareRemote(t, "main")
+	cloneDir := filepath.Join(t.TempDir(), "clone")
+	if err := exec.Command("git", "clone", bareDir, cloneDir).Run(); err != nil {
+		t.Fatalf("Failed to clone: %v", err)
+	}
+	exec.Command("git", "-C", cloneDir, "config", "user.email", "test@example.com").Run()
+	exec.Command("git", "-C", cloneDir, "config", "user.name", "Test User").Run()
+
+	branch, _ := GetCurrentBranch(cloneDir)
+	return cloneDir, branch
+}
+
+func TestLinkChecker_Status(t *testing.T) {
+	repoDir, branch := setupLinkTestRepo(t)
+
+	// Committed and pushed file
+	os.WriteFile(filepath.Join(repoDir, "pushed.txt"), []byte("pushed\n"), 0644)
+	os.WriteFile(filepath.Join(repoDir, "modified.txt"), []byte("original\n"), 0644)
+	exec.Command("git", "-C", repoDir, "add", ".").Run()
+	exec.Command("git", "-C", repoDir, "commit", "-m", "Add files").Run()
+	exec.Command("git", "-C", repoDir, "push", "origin", branch).Run()
+
+	// Committed but not pushed
+	os.MkdirAll(filepath.Join(repoDir, "dir"), 0755)
+	os.WriteFile(filepath.Join(repoDir, "dir", "unpushed.txt"), []byte("unpushed\n"), 0644)
+	exec.Command("git", "-C", repoDir, "add", ".").Run()
+	exec.Command("git", "-C", repoDir, "commit", "-m", "Add unpushed file").Run()
+
+	// Uncommitted change and untracked file
+	os.WriteFile(filepath.Join(repoDir, "modified.txt"), []byte("changed\n"), 0644)
+	os.WriteFile(filepath.Join(repoDir, "untracked.txt"), []byte("untracked\n"), 0644)
+
+	checker, err := NewLinkChecker(repoDir, "origin", branch)
+	if err != nil {
+		t.Fatalf("NewLinkChecker() error = %v, want nil", err)
+	}
+
+	tests := []struct {
+		relPath string
+		want    LinkStatus
+	}{
+		{relPath: "pushed.txt", want: LinkClean},
+		{relPath: "modified.txt", want: LinkModified},
+		{relPath: "untracked.txt", want: LinkUntracked},
+		{relPath: filepath.Join("dir", "unpushed.txt"), want: LinkUnpushed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.relPath, func(t *testing.T) {
+			if got := checker.Status(tt.relPath); got != tt.want {
+				t.Errorf("Status(%q) = %v, want %v", tt.relPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkChecker_BranchNotOnRemote(t *testing.T) {
+	repoDir, _ := setupLinkTestRepo(t)
+	exec.Command("git", "-C", repoDir, "checkout", "-b", "local-only").Run()
+
+	checker, err := NewLinkChecker(repoDir, "origin", "local-only")
+	if err != nil {
+		t.Fatalf("NewLinkChecker() error = %v, want nil", err)
+	}
+
+	if got := checker.Status("README.md"); got != LinkUnpushed {
+		t.Errorf("Status() = %v, want %v", got, LinkUnpushed)
+	}
+}
+
+func TestLinkChecker_PushedCommit(t *testing.T) {
+	repoDir, _ := setupLinkTestRepo(t)
+	commit, _ := GetHeadCommit(repoDir)
+
+	checker, err := NewLinkChecker(repoDir, "origin", commit)
+	if err != nil {
+		t.Fatalf("NewLinkChecker() error = %v, want nil", err)
+	}
+
+	if got := checker.Status("README.md"); got != LinkClean {
+		t.Errorf("Status() = %v, want %v", got, LinkClean)
+	}
+}
+
+func TestLinkChecker_UnpushedCommit(t *testing.T) {
+	repoDir, _ := setupLinkTestRepo(t)
+	os.WriteFile(filepath.Join(repoDir, "new.txt"), []byte("new\n"), 0644)
+	exec.Command("git", "-C", repoDir, "add", ".").Run()
+	exec.Command("git", "-C", repoDir, "commit", "-m", "Add new file").Run()
+	commit, _ := GetHeadCommit(repoDir)
+
+	checker, err := NewLinkChecker(repoDir, "origin", commit)
+	if err != nil {
+		t.Fatalf("NewLinkChecker() error = %v, want nil", err)
+	}
+
+	if got := checker.Status("README.md"); got != LinkUnpushed {
+		t.Errorf("Status() = %v, want %v", got, LinkUnpushed)
+	}
+}
+
+func TestRevisionLinkChecker_IgnoresWorktree(t *testing.T) {
+	repoDir, branch := setupLinkTestRepo(t)
+	commit, _ := GetHeadCommit(repoDir)
+
+	// Local changes do not affect files searched in a revision
+	os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("changed\n"), 0644)
+
+	checker, err := NewRevisionLinkChecker(repoDir, "origin", branch, commit)
+	if err != nil {
+		t.Fatalf("NewRevisionLinkChecker() error = %v, want nil", err)
+	}
+
+	if got := checker.Status("README.md"); got != LinkClean {
+		t.Errorf("Status() = %v, want %v", got, LinkClean)
+	}
+}
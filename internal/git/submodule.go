@@ -0,0 +1,87 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Submodule is a checked-out submodule of a repository.
+type Submodule struct {
+	Path   string // Path relative to the top-level repository root (slash-separated)
+	Commit string // Commit SHA checked out in the submodule
+}
+
+// ListSubmodules returns the checked-out submodules of the repository, including nested submodules.
+// Submodules that are not initialized are skipped, as they have no files in the working tree.
+func ListSubmodules(repoRoot string) ([]Submodule, error) {
+	// Execute: git -C <repoRoot> submodule status --recursive
+	cmd := exec.Command("git", "-C", repoRoot, "submodule", "status", "--recursive")
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list submodules: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	var submodules []Submodule
+	for _, line := range strings.Split(string(output), "\n") {
+		if submodule, ok := parseSubmoduleStatus(line); ok {
+			submodules = append(submodules, submodule)
+		}
+	}
+
+	return submodules, nil
+}
+
+// parseSubmoduleStatus parses a line of git submodule status: "<state><sha> <path>[ (<describe>)]".
+// The state is " " (in sync), "+" (checked-out commit differs from the recorded one),
+// "-" (not initialized) or "U" (merge conflicts).
+func parseSubmoduleStatus(line string) (Submodule, bool) {
+	if len(line) < 2 || line[0] == '-' {
+		return Submodule{}, false
+	}
+
+	commit, path, found := strings.Cut(line[1:], " ")
+	if !found || commit == "" {
+		return Submodule{}, false
+	}
+	if strings.HasSuffix(path, ")") {
+		if i := strings.LastIndex(path, " ("); i >= 0 {
+			path = path[:i]
+		}
+	}
+
+	return Submodule{Path: path, Commit: commit}, true
+}
+
+// FindSubmodule returns the innermost submodule containing the file at relPath
+// (relative to the top-level repository root), or false if the file is not in a submodule.
+func FindSubmodule(submodules []Submodule, relPath string) (Submodule, bool) {
+	relPath = filepath.ToSlash(relPath)
+
+	var found Submodule
+	ok := false
+	for _, submodule := range submodules {
+		if strings.HasPrefix(relPath, submodule.Path+"/") && len(submodule.Path) > len(found.Path) {
+			found = submodule
+			ok = true
+		}
+	}
+
+	return found, ok
+}
+
+// IsInNestedRepo reports whether the file at relPath (relative to repoRoot) belongs to
+// a repository nested in it, such as a checked-out submodule: one of its parent
+// directories below repoRoot has a .git entry.
+func IsInNestedRepo(repoRoot, relPath string) bool {
+	for dir := filepath.Dir(filepath.FromSlash(relPath)); dir != "." && dir != string(filepath.Separator); dir = filepath.Dir(dir) {
+		if _, err := os.Lstat(filepath.Join(repoRoot, dir, ".git")); err == nil {
+			return true
+		}
+	}
+	return false
+}
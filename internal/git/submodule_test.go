@@ -0,0 +1,158 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// addSubmodule adds the repository at subDir as a submodule at relPath and commits it
+func addSubmodule(t *testing.T, repoDir, subDir, relPath string) {
+	t.Helper()
+
+	cmd := exec.Command("git", "-C", repoDir, "-c", "protocol.file.allow=always", "submodule", "add", subDir, relPath)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to add submodule: %v: %s", err, output)
+	}
+	exec.Command("git", "-C", repoDir, "commit", "-m", "Add submodule "+relPath).Run()
+}
+
+func TestListSubmodules(t *testing.T) {
+	libDir := t.TempDir()
+	initTestRepo(t, libDir)
+	libCommit, _ := GetHeadCommit(libDir)
+
+	nestedDir := t.TempDir()
+	initTestRepo(t, nestedDir)
+	nestedCommit, _ := GetHeadCommit(nestedDir)
+	addSubmodule(t, libDir, nestedDir, "nested")
+	libCommit, _ = GetHeadCommit(libDir)
+
+	repoDir := t.TempDir()
+	initTestRepo(t, repoDir)
+	addSubmodule(t, repoDir, libDir, "vendor/lib")
+	exec.Command("git", "-C", repoDir, "-c", "protocol.file.allow=always", "submodule", "update", "--init", "--recursive").Run()
+
+	// Registered, but not initialized
+	addSubmodule(t, repoDir, nestedDir, "uninit")
+	exec.Command("git", "-C", repoDir, "submodule", "deinit", "-f", "uninit").Run()
+
+	submodules, err := ListSubmodules(repoDir)
+	if err != nil {
+		t.Fatalf("ListSubmodules() error = %v, want nil", err)
+	}
+
+	want := []Submodule{
+		{Path: "vendor/lib", Commit: libCommit},
+		{Path: "vendor/lib/nested", Commit: nestedCommit},
+	}
+	if len(submodules) != len(want) {
+		t.Fatalf("ListSubmodules() = %+v, want %+v", submodules, want)
+	}
+	for i := range want {
+		if submodules[i] != want[i] {
+			t.Errorf("ListSubmodules()[%d] = %+v, want %+v", i, submodules[i], want[i])
+		}
+	}
+}
+
+func TestListSubmodules_None(t *testing.T) {
+	repoDir := t.TempDir()
+	initTestRepo(t, repoDir)
+
+	submodules, err := ListSubmodules(repoDir)
+	if err != nil {
+		t.Fatalf("ListSubmodules() error = %v, want nil", err)
+	}
+	if len(submodules) != 0 {
+		t.Errorf("ListSubmodules() = %+v, want empty", submodules)
+	}
+}
+
+func TestParseSubmoduleStatus(t *testing.T) {
+	sha := "0123456789abcdef0123456789abcdef01234567"
+
+	tests := []struct {
+		line   string
+		want   Submodule
+		wantOK bool
+	}{
+		{line: " " + sha + " lib (heads/main)", want: Submodule{Path: "lib", Commit: sha}, wantOK: true},
+		{line: "+" + sha + " vendor/lib (v1.0-1-g0123456)", want: Submodule{Path: "vendor/lib", Commit: sha}, wantOK: true},
+		{line: "U" + sha + " conflict", want: Submodule{Path: "conflict", Commit: sha}, wantOK: true},
+		{line: " " + sha + " dir with space (heads/main)", want: Submodule{Path: "dir with space", Commit: sha}, wantOK: true},
+		{line: "-" + sha + " uninit", wantOK: false},
+		{line: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, ok := parseSubmoduleStatus(tt.line)
+			if ok != tt.wantOK {
+				t.Fatalf("parseSubmoduleStatus() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got != tt.want {
+				t.Errorf("parseSubmoduleStatus() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindSubmodule(t *testing.T) {
+	submodules := []Submodule{
+		{Path: "vendor/lib", Commit: "aaa"},
+		{Path: "vendor/lib/nested", Commit: "bbb"},
+	}
+
+	tests := []struct {
+		relPath string
+		want    string
+		wantOK  bool
+	}{
+		{relPath: "vendor/lib/main.go", want: "vendor/lib", wantOK: true},
+		{relPath: "vendor/lib/nested/file.txt", want: "vendor/lib/nested", wantOK: true},
+		{relPath: filepath.Join("vendor", "lib", "x.go"), want: "vendor/lib", wantOK: true},
+		{relPath: "vendor/library/main.go", wantOK: false},
+		{relPath: "main.go", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.relPath, func(t *testing.T) {
+			got, ok := FindSubmodule(submodules, tt.relPath)
+			if ok != tt.wantOK {
+				t.Fatalf("FindSubmodule() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got.Path != tt.want {
+				t.Errorf("FindSubmodule() = %v, want %v", got.Path, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsInNestedRepo(t *testing.T) {
+	repoDir := t.TempDir()
+	initTestRepo(t, repoDir)
+	os.MkdirAll(filepath.Join(repoDir, "vendor", "lib", "src"), 0755)
+	os.WriteFile(filepath.Join(repoDir, "vendor", "lib", ".git"), []byte("gitdir: ../../.git/modules/lib\n"), 0644)
+	os.MkdirAll(filepath.Join(repoDir, "nested", ".git"), 0755)
+
+	tests := []struct {
+		relPath string
+		want    bool
+	}{
+		{relPath: "vendor/lib/main.go", want: true},
+		{relPath: filepath.Join("vendor", "lib", "src", "x.go"), want: true},
+		{relPath: "nested/file.txt", want: true},
+		{relPath: "vendor/main.go", want: false},
+		{relPath: "main.go", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.relPath, func(t *testing.T) {
+			if got := IsInNestedRepo(repoDir, tt.relPath); got != tt.want {
+				t.Errorf("IsInNestedRepo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
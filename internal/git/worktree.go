@@ -0,0 +1,57 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// GetCommonDir returns the absolute path of the common git directory of the repository.
+// The main worktree and its linked worktrees share the same common directory.
+func GetCommonDir(repoRoot string) (string, error) {
+	// Execute: git -C <repoRoot> rev-parse --git-common-dir
+	// The output may be relative to <repoRoot> (e.g., ".git" for the main worktree)
+	cmd := exec.Command("git", "-C", repoRoot, "rev-parse", "--git-common-dir")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get common git directory: %w", err)
+	}
+
+	commonDir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(repoRoot, commonDir)
+	}
+	commonDir, err = filepath.Abs(commonDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	// Resolve symbolic links so that the same directory reached through different paths is equal
+	if resolved, err := filepath.EvalSymlinks(commonDir); err == nil {
+		commonDir = resolved
+	}
+
+	return commonDir, nil
+}
+
+// MergeWorktrees returns the repository roots with linked worktrees of the same repository merged:
+// for each common git directory, only the first of the given roots is kept.
+func MergeWorktrees(repoRoots []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var merged []string
+
+	for _, repoRoot := range repoRoots {
+		commonDir, err := GetCommonDir(repoRoot)
+		if err != nil {
+			return nil, err
+		}
+
+		if !seen[commonDir] {
+			seen[commonDir] = true
+			merged = append(merged, repoRoot)
+		}
+	}
+
+	return merged, nil
+}
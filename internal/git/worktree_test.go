@@ -0,0 +1,71 @@
+package git
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// addWorktree adds a linked worktree on a new branch and returns its path
+func addWorktree(t *testing.T, repoDir, branch string) string {
+	t.Helper()
+
+	worktreeDir := filepath.Join(t.TempDir(), branch)
+	cmd := exec.Command("git", "-C", repoDir, "worktree", "add", "-b", branch, worktreeDir)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to add worktree: %v: %s", err, output)
+	}
+	return worktreeDir
+}
+
+func TestGetCommonDir(t *testing.T) {
+	repoDir := t.TempDir()
+	initTestRepo(t, repoDir)
+	worktreeDir := addWorktree(t, repoDir, "feature")
+
+	mainCommonDir, err := GetCommonDir(repoDir)
+	if err != nil {
+		t.Fatalf("GetCommonDir() error = %v, want nil", err)
+	}
+	worktreeCommonDir, err := GetCommonDir(worktreeDir)
+	if err != nil {
+		t.Fatalf("GetCommonDir() error = %v, want nil", err)
+	}
+
+	if !filepath.IsAbs(mainCommonDir) {
+		t.Errorf("GetCommonDir() = %v, want absolute path", mainCommonDir)
+	}
+	if mainCommonDir != worktreeCommonDir {
+		t.Errorf("GetCommonDir() = %v for worktree, want %v", worktreeCommonDir, mainCommonDir)
+	}
+}
+
+func TestGetCommonDir_NotRepository(t *testing.T) {
+	if _, err := GetCommonDir(t.TempDir()); err == nil {
+		t.Error("GetCommonDir() expected error for a non-repository, got nil")
+	}
+}
+
+func TestMergeWorktrees(t *testing.T) {
+	repoDir := t.TempDir()
+	initTestRepo(t, repoDir)
+	worktreeDir := addWorktree(t, repoDir, "feature")
+
+	otherDir := t.TempDir()
+	initTestRepo(t, otherDir)
+
+	merged, err := MergeWorktrees([]string{worktreeDir, otherDir, repoDir})
+	if err != nil {
+		t.Fatalf("MergeWorktrees() error = %v, want nil", err)
+	}
+
+	want := []string{worktreeDir, otherDir}
+	if len(merged) != len(want) {
+		t.Fatalf("MergeWorktrees() = %v, want %v", merged, want)
+	}
+	for i := range want {
+		if merged[i] != want[i] {
+			t.Errorf("MergeWorktrees()[%d] = %v, want %v", i, merged[i], want[i])
+		}
+	}
+}
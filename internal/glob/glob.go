@@ -0,0 +1,133 @@
+package glob
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Glob matches slash-separated paths relative to the root of a search.
+type Glob struct {
+	re    *regexp.Regexp // Matches the path or one of its parent directories
+	exact *regexp.Regexp // Matches the path itself
+}
+
+// Compile converts a glob into a Glob.
+//
+// The semantics follow ripgrep's -g globs, which are based on .gitignore:
+//   - A glob containing "/" is anchored to the root; otherwise it matches at any depth.
+//   - A glob matching a directory also matches all files under it.
+//   - "*" and "?" match within a path segment, and "**" matches across path segments.
+//   - "[...]" (or "[!...]") matches a character class, and "{a,b}" any of the alternatives.
+func Compile(glob string) (*Glob, error) {
+	g := strings.TrimSuffix(glob, "/")
+	anchored := strings.Contains(g, "/")
+	g = strings.TrimPrefix(g, "/")
+	if g == "" {
+		return nil, fmt.Errorf("empty glob")
+	}
+
+	var expr strings.Builder
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("^(?:.*/)?")
+	}
+
+	braces := 0
+	for i := 0; i < len(g); i++ {
+		switch {
+		case strings.HasPrefix(g[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(g[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case g[i] == '*':
+			expr.WriteString("[^/]*")
+		case g[i] == '?':
+			expr.WriteString("[^/]")
+		case g[i] == '[':
+			end := strings.IndexByte(g[i+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated character class")
+			}
+			class := g[i+1 : i+1+end]
+			if negated, found := strings.CutPrefix(class, "!"); found {
+				class = "^" + negated
+			}
+			expr.WriteString("[" + class + "]")
+			i += end + 1
+		case g[i] == '{':
+			expr.WriteString("(?:")
+			braces++
+		case g[i] == '}' && braces > 0:
+			expr.WriteString(")")
+			braces--
+		case g[i] == ',' && braces > 0:
+			expr.WriteString("|")
+		case g[i] == '\\' && i+1 < len(g):
+			i++
+			expr.WriteString(regexp.QuoteMeta(g[i : i+1]))
+		default:
+			expr.WriteString(regexp.QuoteMeta(g[i : i+1]))
+		}
+	}
+	if braces > 0 {
+		return nil, fmt.Errorf("unterminated alternatives")
+	}
+
+	exact, err := regexp.Compile(expr.String() + "$")
+	if err != nil {
+		return nil, err
+	}
+	// The file itself, or files under the matching directory
+	re := regexp.MustCompile(expr.String() + "(?:/.*)?$")
+
+	return &Glob{re: re, exact: exact}, nil
+}
+
+// Match reports whether the glob matches the path.
+func (g *Glob) Match(relPath string) bool {
+	return g.re.MatchString(relPath)
+}
+
+// MatchExact reports whether the glob matches the path itself, rather than one of its parent directories.
+func (g *Glob) MatchExact(relPath string) bool {
+	return g.exact.MatchString(relPath)
+}
+
+// Set is a list of globs selecting files, each optionally negated with "!", as given to ripgrep with -g.
+type Set struct {
+	globs    []*Glob
+	negated  []bool
+	includes bool // Whether any glob is not negated
+}
+
+// NewSet compiles the globs of a set.
+func NewSet(globs []string) (*Set, error) {
+	s := &Set{}
+	for _, pattern := range globs {
+		p, negated := strings.CutPrefix(pattern, "!")
+		g, err := Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+		s.globs = append(s.globs, g)
+		s.negated = append(s.negated, negated)
+		s.includes = s.includes || !negated
+	}
+	return s, nil
+}
+
+// Selects reports whether the set selects the path.
+// As with ripgrep, the last matching glob wins, and a path matching no glob is selected
+// only if all globs are negated.
+func (s *Set) Selects(relPath string) bool {
+	for i := len(s.globs) - 1; i >= 0; i-- {
+		if s.globs[i].Match(relPath) {
+			return !s.negated[i]
+		}
+	}
+	return !s.includes
+}
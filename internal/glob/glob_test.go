@@ -0,0 +1,107 @@
+package glob
+
+import "testing"
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		glob    string
+		path    string
+		matches bool
+	}{
+		{glob: "*.go", path: "main.go", matches: true},
+		{glob: "*.go", path: "a/b/main.go", matches: true},
+		{glob: "*.go", path: "main.go.txt", matches: false},
+		{glob: "src/*.go", path: "src/main.go", matches: true},
+		{glob: "src/*.go", path: "src/a/main.go", matches: false},
+		{glob: "src/*.go", path: "lib/src/main.go", matches: false},
+		{glob: "/main.go", path: "main.go", matches: true},
+		{glob: "/main.go", path: "a/main.go", matches: false},
+		{glob: "src/**", path: "src/a/b.txt", matches: true},
+		{glob: "**/test/*.go", path: "a/test/b.go", matches: true},
+		{glob: "vendor", path: "a/vendor/lib.go", matches: true},
+		{glob: "vendor/", path: "vendor/lib.go", matches: true},
+		{glob: "file?.txt", path: "file1.txt", matches: true},
+		{glob: "file?.txt", path: "file10.txt", matches: false},
+		{glob: "[ab].txt", path: "b.txt", matches: true},
+		{glob: "[!ab].txt", path: "b.txt", matches: false},
+		{glob: "*.{go,md}", path: "README.md", matches: true},
+		{glob: "*.{go,md}", path: "main.txt", matches: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.glob+" "+tt.path, func(t *testing.T) {
+			g, err := Compile(tt.glob)
+			if err != nil {
+				t.Fatalf("Compile() error = %v, want nil", err)
+			}
+			if got := g.Match(tt.path); got != tt.matches {
+				t.Errorf("Compile(%q).Match(%q) = %v, want %v", tt.glob, tt.path, got, tt.matches)
+			}
+		})
+	}
+
+	for _, glob := range []string{"", "[ab", "{a,b"} {
+		if _, err := Compile(glob); err == nil {
+			t.Errorf("Compile(%q) expected error, got nil", glob)
+		}
+	}
+}
+
+func TestGlob_MatchExact(t *testing.T) {
+	tests := []struct {
+		glob    string
+		path    string
+		matches bool
+	}{
+		{glob: "docs/*", path: "docs/a.md", matches: true},
+		{glob: "docs/*", path: "docs/a/b.md", matches: false},
+		{glob: "vendor", path: "a/vendor", matches: true},
+		{glob: "vendor", path: "vendor/lib.go", matches: false},
+		{glob: "**/x/*", path: "x/x/y", matches: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.glob+" "+tt.path, func(t *testing.T) {
+			g, err := Compile(tt.glob)
+			if err != nil {
+				t.Fatalf("Compile() error = %v, want nil", err)
+			}
+			if got := g.MatchExact(tt.path); got != tt.matches {
+				t.Errorf("Compile(%q).MatchExact(%q) = %v, want %v", tt.glob, tt.path, got, tt.matches)
+			}
+		})
+	}
+}
+
+func TestSet_Selects(t *testing.T) {
+	tests := []struct {
+		name  string
+		globs []string
+		path  string
+		want  bool
+	}{
+		{name: "no globs", globs: nil, path: "main.go", want: true},
+		{name: "include", globs: []string{"*.go"}, path: "main.go", want: true},
+		{name: "not included", globs: []string{"*.go"}, path: "README.md", want: false},
+		{name: "exclude only", globs: []string{"!vendor/**"}, path: "main.go", want: true},
+		{name: "excluded", globs: []string{"!vendor/**"}, path: "vendor/lib.go", want: false},
+		{name: "last match wins", globs: []string{"*.go", "!*_test.go"}, path: "main_test.go", want: false},
+		{name: "last match wins reinclude", globs: []string{"!*_test.go", "main_test.go"}, path: "main_test.go", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSet(tt.globs)
+			if err != nil {
+				t.Fatalf("NewSet() error = %v, want nil", err)
+			}
+			if got := s.Selects(tt.path); got != tt.want {
+				t.Errorf("Selects(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewSet([]string{"*.go", "!{a"}); err == nil {
+		t.Error("NewSet() expected error for an invalid glob, got nil")
+	}
+}
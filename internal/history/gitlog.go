@@ -0,0 +1,265 @@
+package history
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Match represents a single line added or removed by a commit that matches the pattern.
+type Match struct {
+	Commit     string // Full commit SHA
+	Author     string // Author name
+	Date       string // Author date in strict ISO 8601 format
+	RelPath    string // Path of the file after the commit (before the commit for removed lines)
+	LineNumber int    // Line number in the file after the commit (before the commit for removed lines)
+	LineText   string // The added or removed line content
+	Removed    bool   // Whether the line was removed (false = added)
+}
+
+// SearchOptions contains optional parameters for history search.
+type SearchOptions struct {
+	IgnoreCase    bool     // Enable case-insensitive search (--regexp-ignore-case)
+	Globs         []string // Glob patterns to filter files (ripgrep-style, converted to pathspecs)
+	FixedStrings  bool     // Treat pattern as literal string and use -S instead of -G
+	MaxLineLength int      // Maximum length of line text in output (0 = no limit)
+	Rev           string   // Revision to start the history from (default: HEAD)
+}
+
+// headerPrefix marks the commit header lines in the git log output.
+const headerPrefix = "\x01"
+
+// SearchHistory searches the commit history of the given repository with git log -G (or -S).
+// The onMatch callback is called for each added or removed line that matches the pattern,
+// from the newest commit to the oldest.
+func SearchHistory(pattern, repoRoot string, opts SearchOptions, onMatch func(Match) error) error {
+	matchLine, err := newLineMatcher(pattern, opts)
+	if err != nil {
+		return err
+	}
+
+	// Build git log arguments
+	args := []string{
+		"-C", repoRoot,
+		"-c", "core.quotePath=false",
+		"log", "--no-color", "--no-ext-diff", "--no-renames",
+		"-p", "-U0", "--src-prefix=a/", "--dst-prefix=b/",
+		"--format=" + headerPrefix + "%H%x00%an%x00%aI",
+	}
+
+	// -S finds commits changing the number of occurrences of a literal string,
+	// -G finds commits whose diff contains a line matching a regex
+	if opts.FixedStrings {
+		args = append(args, "-S"+pattern)
+	} else {
+		args = append(args, "-G"+pattern)
+	}
+
+	if opts.IgnoreCase {
+		args = append(args, "--regexp-ignore-case")
+	}
+
+	rev := opts.Rev
+	if rev == "" {
+		rev = "HEAD"
+	}
+	args = append(args, rev, "--")
+
+	for _, glob := range opts.Globs {
+		args = append(args, globToPathspec(glob))
+	}
+
+	// Execute: git -C <repoRoot> log -p -U0 -G<pattern> [options] <rev> -- [pathspecs]
+	cmd := exec.Command("git", args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start git log: %w", err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+
+	// Increase buffer size to handle very long lines in diffs (default is 64KB, set to 10MB)
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, 10*1024*1024)
+
+	var (
+		current          Match // Commit information of the current commit
+		oldPath, newPath string
+		oldLine, newLine int
+		inHunk           bool
+	)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, headerPrefix):
+			// Commit header: <sha>\0<author>\0<date>
+			fields := strings.SplitN(strings.TrimPrefix(line, headerPrefix), "\x00", 3)
+			if len(fields) != 3 {
+				continue
+			}
+			current = Match{Commit: fields[0], Author: fields[1], Date: fields[2]}
+			inHunk = false
+
+		case strings.HasPrefix(line, "diff "):
+			oldPath, newPath = "", ""
+			inHunk = false
+
+		case !inHunk && strings.HasPrefix(line, "--- "):
+			oldPath = parseDiffPath(strings.TrimPrefix(line, "--- "), "a/")
+
+		case !inHunk && strings.HasPrefix(line, "+++ "):
+			newPath = parseDiffPath(strings.TrimPrefix(line, "+++ "), "b/")
+
+		case strings.HasPrefix(line, "@@ "):
+			oldLine, newLine, inHunk = parseHunkHeader(line)
+
+		case inHunk && strings.HasPrefix(line, "-"):
+			text := strings.TrimPrefix(line, "-")
+			if matchLine(text) {
+				if err := emit(current, oldPath, oldLine, text, true, opts, onMatch); err != nil {
+					return err
+				}
+			}
+			oldLine++
+
+		case inHunk && strings.HasPrefix(line, "+"):
+			text := strings.TrimPrefix(line, "+")
+			if matchLine(text) {
+				if err := emit(current, newPath, newLine, text, false, opts, onMatch); err != nil {
+					return err
+				}
+			}
+			newLine++
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading git log output: %w", err)
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("git log failed: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// emit builds a Match for a changed line and passes it to the callback.
+func emit(commit Match, relPath string, lineNumber int, lineText string, removed bool, opts SearchOptions, onMatch func(Match) error) error {
+	// Remove trailing carriage return (CRLF files)
+	lineText = strings.TrimRight(lineText, "\r")
+
+	// Truncate line text if MaxLineLength is specified and line exceeds the limit
+	if opts.MaxLineLength > 0 && len(lineText) > opts.MaxLineLength {
+		lineText = lineText[:opts.MaxLineLength] + "..."
+	}
+
+	match := commit
+	match.RelPath = relPath
+	match.LineNumber = lineNumber
+	match.LineText = lineText
+	match.Removed = removed
+
+	if err := onMatch(match); err != nil {
+		return fmt.Errorf("callback error: %w", err)
+	}
+	return nil
+}
+
+// newLineMatcher returns a function reporting whether a changed line matches the pattern.
+// git log selects the commits, and the matcher selects the lines to report within their diffs.
+func newLineMatcher(pattern string, opts SearchOptions) (func(string) bool, error) {
+	if opts.FixedStrings {
+		if opts.IgnoreCase {
+			lowerPattern := strings.ToLower(pattern)
+			return func(text string) bool {
+				return strings.Contains(strings.ToLower(text), lowerPattern)
+			}, nil
+		}
+		return func(text string) bool {
+			return strings.Contains(text, pattern)
+		}, nil
+	}
+
+	expr := pattern
+	if opts.IgnoreCase {
+		expr = "(?i)" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	return re.MatchString, nil
+}
+
+// parseDiffPath extracts the file path from a "---" or "+++" line of a diff.
+// Returns an empty string for /dev/null.
+func parseDiffPath(path, prefix string) string {
+	// git terminates paths containing spaces with a tab
+	path = strings.TrimSuffix(path, "\t")
+
+	// Paths with special characters are quoted in C style
+	if strings.HasPrefix(path, `"`) {
+		if unquoted, err := strconv.Unquote(path); err == nil {
+			path = unquoted
+		}
+	}
+
+	if path == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(path, prefix)
+}
+
+// parseHunkHeader parses a hunk header ("@@ -<old>[,<count>] +<new>[,<count>] @@")
+// and returns the first old and new line numbers.
+func parseHunkHeader(line string) (oldLine, newLine int, ok bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 {
+		return 0, 0, false
+	}
+
+	oldStart, _, _ := strings.Cut(strings.TrimPrefix(fields[1], "-"), ",")
+	newStart, _, _ := strings.Cut(strings.TrimPrefix(fields[2], "+"), ",")
+
+	oldLine, err := strconv.Atoi(oldStart)
+	if err != nil {
+		return 0, 0, false
+	}
+	newLine, err = strconv.Atoi(newStart)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return oldLine, newLine, true
+}
+
+// globToPathspec converts a ripgrep-style glob (optionally negated with "!") into a git pathspec.
+// Like ripgrep, a glob without a slash matches files in any directory.
+func globToPathspec(glob string) string {
+	magic := "glob"
+	if negated, found := strings.CutPrefix(glob, "!"); found {
+		magic = "glob,exclude"
+		glob = negated
+	}
+
+	if strings.Contains(glob, "/") {
+		glob = strings.TrimPrefix(glob, "/")
+	} else {
+		glob = "**/" + glob
+	}
+
+	return ":(" + magic + ")" + glob
+}
@@ -0,0 +1,255 @@
+package history
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupHistoryRepo creates a Git repository and returns its path
+func setupHistoryRepo(t *testing.T) string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	if err := exec.Command("git", "-C", tmpDir, "init").Run(); err != nil {
+		t.Fatalf("Failed to init git repo: %v", err)
+	}
+	exec.Command("git", "-C", tmpDir, "config", "user.email", "test@example.com").Run()
+	exec.Command("git", "-C", tmpDir, "config", "user.name", "Test User").Run()
+
+	return tmpDir
+}
+
+// commitHistoryFile writes a file, commits it, and returns the commit SHA
+func commitHistoryFile(t *testing.T, repoDir, relPath, content string) string {
+	t.Helper()
+
+	filePath := filepath.Join(repoDir, relPath)
+	os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	exec.Command("git", "-C", repoDir, "add", "-A").Run()
+	if err := exec.Command("git", "-C", repoDir, "commit", "-m", "Update "+relPath).Run(); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+
+	output, err := exec.Command("git", "-C", repoDir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatalf("Failed to get HEAD commit: %v", err)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// collectMatches runs SearchHistory and returns all matches
+func collectMatches(t *testing.T, pattern, repoDir string, opts SearchOptions) []Match {
+	t.Helper()
+
+	var matches []Match
+	err := SearchHistory(pattern, repoDir, opts, func(m Match) error {
+		matches = append(matches, m)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SearchHistory() error = %v, want nil", err)
+	}
+	return matches
+}
+
+func TestSearchHistory(t *testing.T) {
+	repoDir := setupHistoryRepo(t)
+	addCommit := commitHistoryFile(t, repoDir, "src/api.go", "package api\n\nfunc OldAPI() {}\n")
+	commitHistoryFile(t, repoDir, "src/other.go", "package api\n")
+	removeCommit := commitHistoryFile(t, repoDir, "src/api.go", "package api\n\nfunc NewAPI() {}\n")
+
+	matches := collectMatches(t, "OldAPI", repoDir, SearchOptions{})
+
+	if len(matches) != 2 {
+		t.Fatalf("SearchHistory() found %d matches, want 2: %+v", len(matches), matches)
+	}
+
+	// Newest commit first
+	removed := matches[0]
+	if removed.Commit != removeCommit || !removed.Removed {
+		t.Errorf("matches[0] = %+v, want removal in %s", removed, removeCommit)
+	}
+	if removed.RelPath != "src/api.go" || removed.LineNumber != 3 || removed.LineText != "func OldAPI() {}" {
+		t.Errorf("matches[0] = %+v, want src/api.go:3 func OldAPI() {}", removed)
+	}
+
+	added := matches[1]
+	if added.Commit != addCommit || added.Removed {
+		t.Errorf("matches[1] = %+v, want addition in %s", added, addCommit)
+	}
+	if added.RelPath != "src/api.go" || added.LineNumber != 3 {
+		t.Errorf("matches[1] = %+v, want src/api.go:3", added)
+	}
+	if added.Author != "Test User" {
+		t.Errorf("Author = %v, want Test User", added.Author)
+	}
+	if added.Date == "" {
+		t.Error("Date should not be empty")
+	}
+}
+
+func TestSearchHistory_OnlyMatchingLinesReported(t *testing.T) {
+	repoDir := setupHistoryRepo(t)
+	commitHistoryFile(t, repoDir, "file.txt", "first\nTODO: fix\nlast\n")
+
+	matches := collectMatches(t, "TODO", repoDir, SearchOptions{})
+
+	if len(matches) != 1 {
+		t.Fatalf("SearchHistory() found %d matches, want 1: %+v", len(matches), matches)
+	}
+	if matches[0].LineNumber != 2 || matches[0].LineText != "TODO: fix" {
+		t.Errorf("matches[0] = %+v, want file.txt:2 TODO: fix", matches[0])
+	}
+}
+
+func TestSearchHistory_Options(t *testing.T) {
+	repoDir := setupHistoryRepo(t)
+	commitHistoryFile(t, repoDir, "main.go", "call(a.b)\n")
+	commitHistoryFile(t, repoDir, "docs/readme.txt", "CALL(aXb)\n")
+
+	tests := []struct {
+		name    string
+		pattern string
+		opts    SearchOptions
+		want    []string
+	}{
+		{
+			name:    "regex",
+			pattern: `call\(a.b\)`,
+			want:    []string{"main.go"},
+		},
+		{
+			name:    "ignore case",
+			pattern: `call\(a.b\)`,
+			opts:    SearchOptions{IgnoreCase: true},
+			want:    []string{"docs/readme.txt", "main.go"},
+		},
+		{
+			name:    "fixed strings",
+			pattern: "call(a.b)",
+			opts:    SearchOptions{FixedStrings: true},
+			want:    []string{"main.go"},
+		},
+		{
+			name:    "fixed strings ignore case",
+			pattern: "CALL(A",
+			opts:    SearchOptions{FixedStrings: true, IgnoreCase: true},
+			want:    []string{"docs/readme.txt", "main.go"},
+		},
+		{
+			name:    "glob",
+			pattern: "call",
+			opts:    SearchOptions{IgnoreCase: true, Globs: []string{"*.txt"}},
+			want:    []string{"docs/readme.txt"},
+		},
+		{
+			name:    "negated glob",
+			pattern: "call",
+			opts:    SearchOptions{IgnoreCase: true, Globs: []string{"!docs/**"}},
+			want:    []string{"main.go"},
+		},
+		{
+			name:    "rev",
+			pattern: "call",
+			opts:    SearchOptions{IgnoreCase: true, Rev: "HEAD~1"},
+			want:    []string{"main.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := collectMatches(t, tt.pattern, repoDir, tt.opts)
+
+			var got []string
+			for _, m := range matches {
+				got = append(got, m.RelPath)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("SearchHistory() files = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchHistory_PathWithSpace(t *testing.T) {
+	repoDir := setupHistoryRepo(t)
+	// Diff prefixes are pinned, so user settings must not affect the paths
+	exec.Command("git", "-C", repoDir, "config", "diff.noprefix", "true").Run()
+	exec.Command("git", "-C", repoDir, "config", "diff.mnemonicPrefix", "true").Run()
+	commitHistoryFile(t, repoDir, "dir name/a b.txt", "TODO: fix\n")
+	commitHistoryFile(t, repoDir, "dir name/a b.txt", "done\n")
+
+	matches := collectMatches(t, "TODO", repoDir, SearchOptions{})
+
+	if len(matches) != 2 {
+		t.Fatalf("SearchHistory() found %d matches, want 2: %+v", len(matches), matches)
+	}
+	for _, m := range matches {
+		if m.RelPath != "dir name/a b.txt" {
+			t.Errorf("RelPath = %q, want %q", m.RelPath, "dir name/a b.txt")
+		}
+	}
+}
+
+func TestSearchHistory_UnknownRevision(t *testing.T) {
+	repoDir := setupHistoryRepo(t)
+	commitHistoryFile(t, repoDir, "file.txt", "content\n")
+
+	err := SearchHistory("content", repoDir, SearchOptions{Rev: "nonexistent"}, func(Match) error { return nil })
+	if err == nil {
+		t.Error("SearchHistory() expected error for unknown revision, got nil")
+	}
+}
+
+func TestParseHunkHeader(t *testing.T) {
+	tests := []struct {
+		line    string
+		wantOld int
+		wantNew int
+		wantOK  bool
+	}{
+		{line: "@@ -3 +3 @@", wantOld: 3, wantNew: 3, wantOK: true},
+		{line: "@@ -10,2 +12,0 @@ func main() {", wantOld: 10, wantNew: 12, wantOK: true},
+		{line: "@@ -0,0 +1,5 @@", wantOld: 0, wantNew: 1, wantOK: true},
+		{line: "@@ invalid", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			gotOld, gotNew, gotOK := parseHunkHeader(tt.line)
+			if gotOK != tt.wantOK {
+				t.Fatalf("parseHunkHeader() ok = %v, want %v", gotOK, tt.wantOK)
+			}
+			if gotOK && (gotOld != tt.wantOld || gotNew != tt.wantNew) {
+				t.Errorf("parseHunkHeader() = (%d, %d), want (%d, %d)", gotOld, gotNew, tt.wantOld, tt.wantNew)
+			}
+		})
+	}
+}
+
+func TestGlobToPathspec(t *testing.T) {
+	tests := []struct {
+		glob string
+		want string
+	}{
+		{glob: "*.go", want: ":(glob)**/*.go"},
+		{glob: "!*_test.go", want: ":(glob,exclude)**/*_test.go"},
+		{glob: "src/**", want: ":(glob)src/**"},
+		{glob: "/root.txt/x", want: ":(glob)root.txt/x"},
+		{glob: "!vendor/**", want: ":(glob,exclude)vendor/**"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.glob, func(t *testing.T) {
+			if got := globToPathspec(tt.glob); got != tt.want {
+				t.Errorf("globToPathspec() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -9,21 +9,110 @@ import (
 
 // SearchResult represents a single search match with all required information for output.
 type SearchResult struct {
-	Repository  string // "owner/repo" format
-	LocalPath   string // e.g., "src/main.go:12"
-	MatchedLine string // The matched line content
-	GitHubURL   string // Full GitHub URL with line number
+	Repository  string   // "owner/repo" format
+	LocalPath   string   // e.g., "src/main.go:12"
+	MatchedLine string   // The matched line content
+	URL         string   // Full file URL on the hosting provider with line number
+	LinkStatus  string   // Whether the local file matches the linked revision (e.g., "clean", "modified")
+	Commit      string   // Commit SHA (history search or blame)
+	Author      string   // Commit author (history search or blame)
+	Date        string   // Commit author date (history search)
+	Change      string   // Whether the line was "added" or "removed" by the commit (history search)
+	Ref         string   // Comma-separated branches containing the match (branch search)
+	AuthorEmail string   // Author email of the commit that last changed the line (blame)
+	CommitDate  string   // Date of the commit that last changed the line (blame)
+	Owners      string   // Comma-separated CODEOWNERS owners of the file
+	Worktree    string   // Root of the worktree the match was found in
+	Branch      string   // Branch checked out in the worktree (empty if HEAD is detached)
+	Context     []string // Lines from the first context line before the match to the last one after it
+	MatchedText []string // Matched parts of the line, in order
+	Pattern     string   // Comma-separated names of the patterns that matched the line
+	RuleID      string   // ID of the rule that reported the finding
+	Severity    string   // Severity of the rule ("error", "warning" or "info")
+	Message     string   // Message of the rule
+	Fix         string   // Fix hint of the rule
+}
+
+// Column identifies an optional column that is output after the standard columns.
+type Column string
+
+const (
+	ColumnLinkStatus  Column = "link_status"
+	ColumnCommit      Column = "commit"
+	ColumnAuthor      Column = "author"
+	ColumnDate        Column = "date"
+	ColumnChange      Column = "change"
+	ColumnRef         Column = "ref"
+	ColumnAuthorEmail Column = "author_email"
+	ColumnCommitDate  Column = "commit_date"
+	ColumnOwners      Column = "owners"
+	ColumnWorktree    Column = "worktree"
+	ColumnBranch      Column = "branch"
+	ColumnContext     Column = "context"
+	ColumnMatchedText Column = "matched_text"
+	ColumnPattern     Column = "pattern"
+	ColumnRuleID      Column = "rule_id"
+	ColumnSeverity    Column = "severity"
+	ColumnMessage     Column = "message"
+	ColumnFix         Column = "fix"
+)
+
+// value returns the value of the optional column for the result.
+func (r SearchResult) value(column Column) string {
+	switch column {
+	case ColumnLinkStatus:
+		return r.LinkStatus
+	case ColumnCommit:
+		return r.Commit
+	case ColumnAuthor:
+		return r.Author
+	case ColumnDate:
+		return r.Date
+	case ColumnChange:
+		return r.Change
+	case ColumnRef:
+		return r.Ref
+	case ColumnAuthorEmail:
+		return r.AuthorEmail
+	case ColumnCommitDate:
+		return r.CommitDate
+	case ColumnOwners:
+		return r.Owners
+	case ColumnWorktree:
+		return r.Worktree
+	case ColumnBranch:
+		return r.Branch
+	case ColumnContext:
+		return escapeLines(r.Context)
+	case ColumnMatchedText:
+		return escapeLines(r.MatchedText)
+	case ColumnPattern:
+		return r.Pattern
+	case ColumnRuleID:
+		return r.RuleID
+	case ColumnSeverity:
+		return r.Severity
+	case ColumnMessage:
+		return r.Message
+	case ColumnFix:
+		return r.Fix
+	default:
+		return ""
+	}
 }
 
 // TSVWriter writes search results in TSV format one by one.
 type TSVWriter struct {
-	writer *bufio.Writer
+	writer  *bufio.Writer
+	columns []Column
 }
 
 // NewTSVWriter creates a new TSVWriter.
-func NewTSVWriter(w io.Writer) *TSVWriter {
+// The given optional columns are output, in order, after the standard columns.
+func NewTSVWriter(w io.Writer, columns ...Column) *TSVWriter {
 	return &TSVWriter{
-		writer: bufio.NewWriter(w),
+		writer:  bufio.NewWriter(w),
+		columns: columns,
 	}
 }
 
@@ -32,12 +121,23 @@ func (tw *TSVWriter) Write(result SearchResult) error {
 	// Sanitize matched line: replace tabs and newlines with spaces
 	sanitized := sanitizeLine(result.MatchedLine)
 
-	// Write TSV line
-	line := fmt.Sprintf("%s\t%s\t%s\t%s\n",
+	fields := []string{
 		result.Repository,
 		result.LocalPath,
 		sanitized,
-		result.GitHubURL)
+		result.URL,
+	}
+	for _, column := range tw.columns {
+		if column == ColumnContext || column == ColumnMatchedText {
+			// Already escaped, keeping leading and trailing spaces
+			fields = append(fields, result.value(column))
+			continue
+		}
+		fields = append(fields, sanitizeLine(result.value(column)))
+	}
+
+	// Write TSV line
+	line := strings.Join(fields, "\t") + "\n"
 
 	if _, err := tw.writer.WriteString(line); err != nil {
 		return fmt.Errorf("failed to write output: %w", err)
@@ -62,3 +162,17 @@ func sanitizeLine(text string) string {
 	text = strings.TrimSpace(text)
 	return text
 }
+
+// escapeLines joins lines (or other values) into a single TSV field. Backslashes, tabs,
+// carriage returns and newlines are escaped as "\\", "\t", "\r" and "\n", and the lines are separated with "\n".
+func escapeLines(lines []string) string {
+	escaped := make([]string, len(lines))
+	for i, line := range lines {
+		line = strings.ReplaceAll(line, "\\", "\\\\")
+		line = strings.ReplaceAll(line, "\t", "\\t")
+		line = strings.ReplaceAll(line, "\r", "\\r")
+		line = strings.ReplaceAll(line, "\n", "\\n")
+		escaped[i] = line
+	}
+	return strings.Join(escaped, "\\n")
+}
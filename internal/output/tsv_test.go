@@ -21,7 +21,7 @@ func TestTSVWriter_Write_SingleResult(t *testing.T) {
 		Repository:  "owner/repo",
 		LocalPath:   "main.go:10",
 		MatchedLine: "package main",
-		GitHubURL:   "https://github.com/owner/repo/blob/main/main.go#L10",
+		URL:         "https://github.com/owner/repo/blob/main/main.go#L10",
 	}
 
 	err := writer.Write(result)
@@ -46,13 +46,13 @@ func TestTSVWriter_Write_MultipleResults(t *testing.T) {
 			Repository:  "owner/repo",
 			LocalPath:   "main.go:10",
 			MatchedLine: "package main",
-			GitHubURL:   "https://github.com/owner/repo/blob/main/main.go#L10",
+			URL:         "https://github.com/owner/repo/blob/main/main.go#L10",
 		},
 		{
 			Repository:  "owner/repo",
 			LocalPath:   "cmd/root.go:25",
 			MatchedLine: "func Execute() error {",
-			GitHubURL:   "https://github.com/owner/repo/blob/main/cmd/root.go#L25",
+			URL:         "https://github.com/owner/repo/blob/main/cmd/root.go#L25",
 		},
 	}
 
@@ -72,6 +72,266 @@ func TestTSVWriter_Write_MultipleResults(t *testing.T) {
 	}
 }
 
+func TestTSVWriter_Write_OptionalColumns(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewTSVWriter(&buf, ColumnLinkStatus)
+
+	result := SearchResult{
+		Repository:  "owner/repo",
+		LocalPath:   "main.go:10",
+		MatchedLine: "package main",
+		URL:         "https://github.com/owner/repo/blob/main/main.go#L10",
+		LinkStatus:  "modified",
+	}
+
+	err := writer.Write(result)
+	if err != nil {
+		t.Fatalf("Write() error = %v, want nil", err)
+	}
+
+	want := "owner/repo\tmain.go:10\tpackage main\thttps://github.com/owner/repo/blob/main/main.go#L10\tmodified\n"
+	got := buf.String()
+
+	if got != want {
+		t.Errorf("Write() = %q, want %q", got, want)
+	}
+}
+
+func TestTSVWriter_Write_HistoryColumns(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewTSVWriter(&buf, ColumnCommit, ColumnAuthor, ColumnDate, ColumnChange)
+
+	result := SearchResult{
+		Repository:  "owner/repo",
+		LocalPath:   "main.go:10",
+		MatchedLine: "OldAPI()",
+		URL:         "https://github.com/owner/repo/commit/0123abc",
+		Commit:      "0123abc",
+		Author:      "Test User",
+		Date:        "2024-01-02T03:04:05+09:00",
+		Change:      "removed",
+	}
+
+	err := writer.Write(result)
+	if err != nil {
+		t.Fatalf("Write() error = %v, want nil", err)
+	}
+
+	want := "owner/repo\tmain.go:10\tOldAPI()\thttps://github.com/owner/repo/commit/0123abc\t0123abc\tTest User\t2024-01-02T03:04:05+09:00\tremoved\n"
+	got := buf.String()
+
+	if got != want {
+		t.Errorf("Write() = %q, want %q", got, want)
+	}
+}
+
+func TestTSVWriter_Write_RefColumn(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewTSVWriter(&buf, ColumnRef)
+
+	result := SearchResult{
+		Repository:  "owner/repo",
+		LocalPath:   "main.go:10",
+		MatchedLine: "package main",
+		URL:         "https://github.com/owner/repo/blob/release/1.0/main.go#L10",
+		Ref:         "release/1.0,origin/release/1.0",
+	}
+
+	err := writer.Write(result)
+	if err != nil {
+		t.Fatalf("Write() error = %v, want nil", err)
+	}
+
+	want := "owner/repo\tmain.go:10\tpackage main\thttps://github.com/owner/repo/blob/release/1.0/main.go#L10\trelease/1.0,origin/release/1.0\n"
+	got := buf.String()
+
+	if got != want {
+		t.Errorf("Write() = %q, want %q", got, want)
+	}
+}
+
+func TestTSVWriter_Write_BlameColumns(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewTSVWriter(&buf, ColumnAuthor, ColumnAuthorEmail, ColumnCommit, ColumnCommitDate)
+
+	result := SearchResult{
+		Repository:  "owner/repo",
+		LocalPath:   "main.go:10",
+		MatchedLine: "// TODO: fix",
+		URL:         "https://github.com/owner/repo/blob/main/main.go#L10",
+		Author:      "Test User",
+		AuthorEmail: "test@example.com",
+		Commit:      "0123abc",
+		CommitDate:  "2024-01-02T03:04:05+09:00",
+	}
+
+	err := writer.Write(result)
+	if err != nil {
+		t.Fatalf("Write() error = %v, want nil", err)
+	}
+
+	want := "owner/repo\tmain.go:10\t// TODO: fix\thttps://github.com/owner/repo/blob/main/main.go#L10\tTest User\ttest@example.com\t0123abc\t2024-01-02T03:04:05+09:00\n"
+	got := buf.String()
+
+	if got != want {
+		t.Errorf("Write() = %q, want %q", got, want)
+	}
+}
+
+func TestTSVWriter_Write_OwnersColumn(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewTSVWriter(&buf, ColumnOwners)
+
+	result := SearchResult{
+		Repository:  "owner/repo",
+		LocalPath:   "main.go:10",
+		MatchedLine: "package main",
+		URL:         "https://github.com/owner/repo/blob/main/main.go#L10",
+		Owners:      "@org/backend,@user",
+	}
+
+	err := writer.Write(result)
+	if err != nil {
+		t.Fatalf("Write() error = %v, want nil", err)
+	}
+
+	want := "owner/repo\tmain.go:10\tpackage main\thttps://github.com/owner/repo/blob/main/main.go#L10\t@org/backend,@user\n"
+	got := buf.String()
+
+	if got != want {
+		t.Errorf("Write() = %q, want %q", got, want)
+	}
+}
+
+func TestTSVWriter_Write_WorktreeColumns(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewTSVWriter(&buf, ColumnWorktree, ColumnBranch)
+
+	result := SearchResult{
+		Repository:  "owner/repo",
+		LocalPath:   "main.go:10",
+		MatchedLine: "package main",
+		URL:         "https://github.com/owner/repo/blob/feature/main.go#L10",
+		Worktree:    "/work/repo-feature",
+		Branch:      "feature",
+	}
+
+	err := writer.Write(result)
+	if err != nil {
+		t.Fatalf("Write() error = %v, want nil", err)
+	}
+
+	want := "owner/repo\tmain.go:10\tpackage main\thttps://github.com/owner/repo/blob/feature/main.go#L10\t/work/repo-feature\tfeature\n"
+	got := buf.String()
+
+	if got != want {
+		t.Errorf("Write() = %q, want %q", got, want)
+	}
+}
+
+func TestTSVWriter_Write_ContextColumn(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewTSVWriter(&buf, ColumnContext)
+
+	result := SearchResult{
+		Repository:  "owner/repo",
+		LocalPath:   "main.go:11",
+		MatchedLine: "\treturn err",
+		URL:         "https://github.com/owner/repo/blob/main/main.go#L10-L12",
+		Context:     []string{"\tif err != nil {", "\treturn err", `}  // C:\path`},
+	}
+
+	err := writer.Write(result)
+	if err != nil {
+		t.Fatalf("Write() error = %v, want nil", err)
+	}
+
+	want := "owner/repo\tmain.go:11\treturn err\thttps://github.com/owner/repo/blob/main/main.go#L10-L12\t" +
+		`\tif err != nil {\n\treturn err\n}  // C:\\path` + "\n"
+	got := buf.String()
+
+	if got != want {
+		t.Errorf("Write() = %q, want %q", got, want)
+	}
+}
+
+func TestTSVWriter_Write_MatchedTextColumn(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewTSVWriter(&buf, ColumnMatchedText)
+
+	result := SearchResult{
+		Repository:  "owner/repo",
+		LocalPath:   "main.go:10:5",
+		MatchedLine: "a := foo (foo)",
+		URL:         "https://github.com/owner/repo/blob/main/main.go#L10",
+		MatchedText: []string{"foo ", "foo"},
+	}
+
+	err := writer.Write(result)
+	if err != nil {
+		t.Fatalf("Write() error = %v, want nil", err)
+	}
+
+	want := "owner/repo\tmain.go:10:5\ta := foo (foo)\thttps://github.com/owner/repo/blob/main/main.go#L10\tfoo \\nfoo\n"
+	got := buf.String()
+
+	if got != want {
+		t.Errorf("Write() = %q, want %q", got, want)
+	}
+}
+
+func TestTSVWriter_Write_PatternColumn(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewTSVWriter(&buf, ColumnPattern)
+
+	result := SearchResult{
+		Repository:  "owner/repo",
+		LocalPath:   "main.go:10",
+		MatchedLine: "token := secret",
+		URL:         "https://github.com/owner/repo/blob/main/main.go#L10",
+		Pattern:     "token,secret",
+	}
+
+	err := writer.Write(result)
+	if err != nil {
+		t.Fatalf("Write() error = %v, want nil", err)
+	}
+
+	want := "owner/repo\tmain.go:10\ttoken := secret\thttps://github.com/owner/repo/blob/main/main.go#L10\ttoken,secret\n"
+	got := buf.String()
+
+	if got != want {
+		t.Errorf("Write() = %q, want %q", got, want)
+	}
+}
+
+func TestTSVWriter_Write_RuleColumns(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewTSVWriter(&buf, ColumnRuleID, ColumnSeverity, ColumnMessage, ColumnFix)
+
+	result := SearchResult{
+		Repository:  "owner/repo",
+		LocalPath:   "main.go:10",
+		MatchedLine: "fmt.Println(x)",
+		URL:         "https://github.com/owner/repo/blob/main/main.go#L10",
+		RuleID:      "no-println",
+		Severity:    "warning",
+		Message:     "Debug output left in code",
+	}
+
+	err := writer.Write(result)
+	if err != nil {
+		t.Fatalf("Write() error = %v, want nil", err)
+	}
+
+	want := "owner/repo\tmain.go:10\tfmt.Println(x)\thttps://github.com/owner/repo/blob/main/main.go#L10\tno-println\twarning\tDebug output left in code\t\n"
+	got := buf.String()
+
+	if got != want {
+		t.Errorf("Write() = %q, want %q", got, want)
+	}
+}
+
 func TestTSVWriter_Write_TabsInMatchedLine(t *testing.T) {
 	var buf bytes.Buffer
 	writer := NewTSVWriter(&buf)
@@ -80,7 +340,7 @@ func TestTSVWriter_Write_TabsInMatchedLine(t *testing.T) {
 		Repository:  "owner/repo",
 		LocalPath:   "test.go:5",
 		MatchedLine: "key\tvalue\tdata",
-		GitHubURL:   "https://github.com/owner/repo/blob/main/test.go#L5",
+		URL:         "https://github.com/owner/repo/blob/main/test.go#L5",
 	}
 
 	err := writer.Write(result)
@@ -105,7 +365,7 @@ func TestTSVWriter_Write_NewlinesInMatchedLine(t *testing.T) {
 		Repository:  "owner/repo",
 		LocalPath:   "test.go:5",
 		MatchedLine: "line1\nline2\rline3",
-		GitHubURL:   "https://github.com/owner/repo/blob/main/test.go#L5",
+		URL:         "https://github.com/owner/repo/blob/main/test.go#L5",
 	}
 
 	err := writer.Write(result)
@@ -129,7 +389,7 @@ func TestTSVWriter_Write_Error(t *testing.T) {
 		Repository:  "owner/repo",
 		LocalPath:   "test.go:5",
 		MatchedLine: "test",
-		GitHubURL:   "https://github.com/owner/repo/blob/main/test.go#L5",
+		URL:         "https://github.com/owner/repo/blob/main/test.go#L5",
 	}
 
 	err := writer.Write(result)
@@ -0,0 +1,119 @@
+package patterns
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Pattern is a search pattern with a name identifying it in the output.
+type Pattern struct {
+	Name  string // Name shown in the pattern column (the pattern itself if not named)
+	Regex string // Pattern passed to ripgrep
+}
+
+// Parse parses a pattern file. Each line is a pattern, or a name and a pattern separated by a tab
+// ("name<TAB>pattern"). Blank lines and lines starting with "#" are ignored.
+func Parse(r io.Reader) ([]Pattern, error) {
+	var patterns []Pattern
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, regex, found := strings.Cut(line, "\t")
+		if !found {
+			regex = name
+		}
+		if regex == "" {
+			return nil, fmt.Errorf("empty pattern for %s", name)
+		}
+		patterns = append(patterns, Pattern{Name: name, Regex: regex})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read pattern file: %w", err)
+	}
+
+	return patterns, nil
+}
+
+// Load reads a pattern file.
+func Load(path string) ([]Pattern, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pattern file: %w", err)
+	}
+	defer file.Close()
+
+	return Parse(file)
+}
+
+// Matcher attributes matches to the patterns that produced them.
+type Matcher struct {
+	patterns []Pattern
+	matchers []func(string) bool
+}
+
+// NewMatcher creates a Matcher for the patterns, interpreted the same way as by the search.
+func NewMatcher(patterns []Pattern, ignoreCase, fixedStrings bool) (*Matcher, error) {
+	m := &Matcher{patterns: patterns}
+
+	for _, p := range patterns {
+		match, err := newTextMatcher(p.Regex, ignoreCase, fixedStrings)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", p.Name, err)
+		}
+		m.matchers = append(m.matchers, match)
+	}
+
+	return m, nil
+}
+
+// Names returns the names of the patterns matching any of the matched texts of a line, in pattern order.
+// If no pattern matches a matched text (e.g., a pattern relying on surrounding text such as "\b"),
+// the patterns matching the line itself are returned.
+func (m *Matcher) Names(matchedTexts []string, line string) []string {
+	names := m.names(matchedTexts)
+	if len(names) == 0 {
+		names = m.names([]string{line})
+	}
+	return names
+}
+
+// names returns the names of the patterns matching any of the texts.
+func (m *Matcher) names(texts []string) []string {
+	var names []string
+	for i, match := range m.matchers {
+		for _, text := range texts {
+			if match(text) {
+				names = append(names, m.patterns[i].Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// newTextMatcher returns a function reporting whether a text contains a match of the pattern.
+func newTextMatcher(pattern string, ignoreCase, fixedStrings bool) (func(string) bool, error) {
+	expr := pattern
+	if fixedStrings {
+		expr = regexp.QuoteMeta(pattern)
+	}
+	if ignoreCase {
+		expr = "(?i)" + expr
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString, nil
+}
@@ -0,0 +1,131 @@
+package patterns
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	content := "# Deprecated APIs\n" +
+		"old-api\toldAPI\\(\n" +
+		"\n" +
+		"legacyCall\r\n" +
+		"  \n" +
+		"with space\tfoo bar\n"
+
+	patterns, err := Parse(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v, want nil", err)
+	}
+
+	want := []Pattern{
+		{Name: "old-api", Regex: `oldAPI\(`},
+		{Name: "legacyCall", Regex: "legacyCall"},
+		{Name: "with space", Regex: "foo bar"},
+	}
+	if len(patterns) != len(want) {
+		t.Fatalf("Parse() = %+v, want %+v", patterns, want)
+	}
+	for i := range want {
+		if patterns[i] != want[i] {
+			t.Errorf("Parse()[%d] = %+v, want %+v", i, patterns[i], want[i])
+		}
+	}
+}
+
+func TestParse_EmptyPattern(t *testing.T) {
+	if _, err := Parse(strings.NewReader("name\t\n")); err == nil {
+		t.Error("Parse() expected error for an empty pattern, got nil")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "patterns.txt")
+	os.WriteFile(path, []byte("a\tfoo\n"), 0644)
+
+	patterns, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+	if len(patterns) != 1 || patterns[0] != (Pattern{Name: "a", Regex: "foo"}) {
+		t.Errorf("Load() = %+v, want [{a foo}]", patterns)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "nonexistent.txt")); err == nil {
+		t.Error("Load() expected error for a missing file, got nil")
+	}
+}
+
+func TestMatcher_Names(t *testing.T) {
+	patterns := []Pattern{
+		{Name: "old", Regex: `old[A-Z]\w*`},
+		{Name: "legacy", Regex: "legacy"},
+		{Name: "inner", Regex: `\Bpi`},
+	}
+
+	tests := []struct {
+		name         string
+		ignoreCase   bool
+		matchedTexts []string
+		line         string
+		want         []string
+	}{
+		{
+			name:         "single",
+			matchedTexts: []string{"oldAPI"},
+			line:         "x := oldAPI()",
+			want:         []string{"old"},
+		},
+		{
+			name:         "multiple",
+			matchedTexts: []string{"legacy", "oldCall"},
+			line:         "legacy(oldCall())",
+			want:         []string{"old", "legacy"},
+		},
+		{
+			name:         "ignore case",
+			ignoreCase:   true,
+			matchedTexts: []string{"LEGACY"},
+			line:         "LEGACY",
+			want:         []string{"legacy"},
+		},
+		{
+			name:         "fall back to line",
+			matchedTexts: []string{"pi"},
+			line:         "use api here",
+			want:         []string{"inner"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMatcher(patterns, tt.ignoreCase, false)
+			if err != nil {
+				t.Fatalf("NewMatcher() error = %v, want nil", err)
+			}
+
+			got := m.Names(tt.matchedTexts, tt.line)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Names() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMatcher_FixedStrings(t *testing.T) {
+	m, err := NewMatcher([]Pattern{{Name: "call", Regex: "call("}}, false, true)
+	if err != nil {
+		t.Fatalf("NewMatcher() error = %v, want nil", err)
+	}
+	if got := m.Names([]string{"call("}, "call(x)"); len(got) != 1 {
+		t.Errorf("Names() = %v, want [call]", got)
+	}
+}
+
+func TestNewMatcher_InvalidPattern(t *testing.T) {
+	if _, err := NewMatcher([]Pattern{{Name: "bad", Regex: "("}}, false, false); err == nil {
+		t.Error("NewMatcher() expected error for an invalid pattern, got nil")
+	}
+}
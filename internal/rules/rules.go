@@ -0,0 +1,180 @@
+package rules
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+
+	"github.com/BurntSushi/toml"
+	"github.com/onozaty/reporg/internal/glob"
+	"github.com/onozaty/reporg/internal/patterns"
+)
+
+// Severities of a rule.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// Rule is a named check reported for every line matching its pattern.
+type Rule struct {
+	ID       string   `toml:"id"`       // Identifier shown in the rule_id column
+	Regex    string   `toml:"regex"`    // Regular expression to search for (exclusive with Fixed)
+	Fixed    string   `toml:"fixed"`    // Literal string to search for (exclusive with Regex)
+	Include  []string `toml:"include"`  // Only check files matching these globs (empty = all)
+	Exclude  []string `toml:"exclude"`  // Skip files matching these globs
+	Severity string   `toml:"severity"` // "error", "warning" or "info" (default: "warning")
+	Message  string   `toml:"message"`  // Description of the finding
+	Fix      string   `toml:"fix"`      // Optional hint on how to fix the finding
+}
+
+// ruleFile represents the contents of a rule file.
+type ruleFile struct {
+	Rules []Rule `toml:"rules"`
+}
+
+// Pattern returns the regular expression searched for the rule
+// (the literal string escaped if the rule is fixed).
+func (r Rule) Pattern() string {
+	if r.Fixed != "" {
+		return regexp.QuoteMeta(r.Fixed)
+	}
+	return r.Regex
+}
+
+// globs returns the ripgrep-style globs selecting the files checked by the rule.
+func (r Rule) globs() []string {
+	globs := slices.Clone(r.Include)
+	for _, exclude := range r.Exclude {
+		globs = append(globs, "!"+exclude)
+	}
+	return globs
+}
+
+// Load reads a rule file.
+func Load(path string) ([]Rule, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rule file: %w", err)
+	}
+	defer file.Close()
+
+	rules, err := Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rule file %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// Parse parses a TOML rule file, in which each rule is a [[rules]] table.
+//
+//	[[rules]]
+//	id = "no-aws-key"
+//	regex = 'AKIA[0-9A-Z]{16}'
+//	exclude = ["*_test.go"]
+//	severity = "error"
+//	message = "AWS access key committed"
+//	fix = "Load the key from the secret store"
+func Parse(r io.Reader) ([]Rule, error) {
+	var file ruleFile
+	meta, err := toml.NewDecoder(r).Decode(&file)
+	if err != nil {
+		return nil, err
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return nil, fmt.Errorf("unknown key %s", undecoded[0])
+	}
+
+	rules := file.Rules
+	ids := make(map[string]bool)
+	for i := range rules {
+		rule := &rules[i]
+		if rule.ID == "" {
+			return nil, fmt.Errorf("rule #%d: id is required", i+1)
+		}
+		if ids[rule.ID] {
+			return nil, fmt.Errorf("rule %s: duplicate id", rule.ID)
+		}
+		ids[rule.ID] = true
+
+		if (rule.Regex == "") == (rule.Fixed == "") {
+			return nil, fmt.Errorf("rule %s: exactly one of regex and fixed is required", rule.ID)
+		}
+		if rule.Message == "" {
+			return nil, fmt.Errorf("rule %s: message is required", rule.ID)
+		}
+		switch rule.Severity {
+		case "":
+			rule.Severity = SeverityWarning
+		case SeverityError, SeverityWarning, SeverityInfo:
+		default:
+			return nil, fmt.Errorf("rule %s: invalid severity %q (must be '%s', '%s' or '%s')", rule.ID, rule.Severity, SeverityError, SeverityWarning, SeverityInfo)
+		}
+		if _, err := glob.NewSet(rule.globs()); err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.ID, err)
+		}
+	}
+
+	return rules, nil
+}
+
+// Matcher attributes matches to the rules that produced them.
+type Matcher struct {
+	rules    []Rule
+	byID     map[string]int
+	patterns *patterns.Matcher
+	files    []*glob.Set // Files selected by each rule
+}
+
+// NewMatcher creates a Matcher for the rules, interpreting patterns the same way as the search.
+func NewMatcher(rules []Rule, ignoreCase bool) (*Matcher, error) {
+	m := &Matcher{
+		rules: rules,
+		byID:  make(map[string]int, len(rules)),
+	}
+
+	var rulePatterns []patterns.Pattern
+	for i, rule := range rules {
+		m.byID[rule.ID] = i
+		rulePatterns = append(rulePatterns, patterns.Pattern{Name: rule.ID, Regex: rule.Pattern()})
+
+		files, err := glob.NewSet(rule.globs())
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.ID, err)
+		}
+		m.files = append(m.files, files)
+	}
+
+	var err error
+	m.patterns, err = patterns.NewMatcher(rulePatterns, ignoreCase, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Patterns returns the patterns of all rules, to be searched for at once.
+func (m *Matcher) Patterns() []string {
+	var result []string
+	for _, rule := range m.rules {
+		result = append(result, rule.Pattern())
+	}
+	return result
+}
+
+// Rules returns the rules reporting a matched line of the file at relPath (slash-separated),
+// in rule order: the rules whose pattern produced the match and whose globs select the file.
+func (m *Matcher) Rules(relPath string, matchedTexts []string, line string) []*Rule {
+	var result []*Rule
+	for _, id := range m.patterns.Names(matchedTexts, line) {
+		i := m.byID[id]
+		if m.files[i].Selects(relPath) {
+			result = append(result, &m.rules[i])
+		}
+	}
+	return result
+}
@@ -0,0 +1,250 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	content := `# Organisation policies
+
+[[rules]]
+id = "no-aws-key"
+regex = 'AKIA[0-9A-Z]{16}\b'   # literal string, no escapes
+exclude = ["*_test.go", "testdata/**"]
+severity = "error"
+message = "AWS access key committed"
+fix = "Load the key from the \"secrets\" store"
+
+[[rules]]
+"id" = "no-println"
+fixed = "fmt.Println("
+include = [
+  "*.go", # Go only
+]
+message = 'Debug output left in code'
+
+[[rules]]
+id = "no-todo"
+regex = '''
+(?x) TODO   # a comment in the regex
+'''
+message = "TODO \u0041"
+`
+
+	rules, err := Parse(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v, want nil", err)
+	}
+
+	want := []Rule{
+		{
+			ID:       "no-aws-key",
+			Regex:    `AKIA[0-9A-Z]{16}\b`,
+			Exclude:  []string{"*_test.go", "testdata/**"},
+			Severity: SeverityError,
+			Message:  "AWS access key committed",
+			Fix:      `Load the key from the "secrets" store`,
+		},
+		{
+			ID:       "no-println",
+			Fixed:    "fmt.Println(",
+			Include:  []string{"*.go"},
+			Severity: SeverityWarning,
+			Message:  "Debug output left in code",
+		},
+		{
+			ID:       "no-todo",
+			Regex:    "(?x) TODO   # a comment in the regex\n",
+			Severity: SeverityWarning,
+			Message:  "TODO A",
+		},
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Errorf("Parse() = %+v, want %+v", rules, want)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "key outside rules",
+			content: "id = \"a\"\n",
+			wantErr: "unknown key id",
+		},
+		{
+			name:    "unsupported table",
+			content: "[settings]\nname = \"a\"\n",
+			wantErr: "unknown key settings",
+		},
+		{
+			name:    "unknown key",
+			content: "[[rules]]\nid = \"a\"\npattern = \"x\"\n",
+			wantErr: "unknown key rules.pattern",
+		},
+		{
+			name:    "unterminated string",
+			content: "[[rules]]\nid = \"a\n",
+			wantErr: "line 2",
+		},
+		{
+			name:    "invalid escape",
+			content: "[[rules]]\nid = \"\\101\"\n",
+			wantErr: "line 2",
+		},
+		{
+			name:    "wrong type",
+			content: "[[rules]]\nid = \"a\"\ninclude = \"*.go\"\n",
+			wantErr: "include",
+		},
+		{
+			name:    "missing id",
+			content: "[[rules]]\nregex = \"x\"\nmessage = \"m\"\n",
+			wantErr: "rule #1: id is required",
+		},
+		{
+			name:    "duplicate id",
+			content: "[[rules]]\nid = \"a\"\nregex = \"x\"\nmessage = \"m\"\n[[rules]]\nid = \"a\"\nregex = \"y\"\nmessage = \"m\"\n",
+			wantErr: "rule a: duplicate id",
+		},
+		{
+			name:    "regex and fixed",
+			content: "[[rules]]\nid = \"a\"\nregex = \"x\"\nfixed = \"x\"\nmessage = \"m\"\n",
+			wantErr: "rule a: exactly one of regex and fixed is required",
+		},
+		{
+			name:    "missing message",
+			content: "[[rules]]\nid = \"a\"\nregex = \"x\"\n",
+			wantErr: "rule a: message is required",
+		},
+		{
+			name:    "invalid glob",
+			content: "[[rules]]\nid = \"a\"\nregex = \"x\"\nmessage = \"m\"\ninclude = [\"*.{go\"]\n",
+			wantErr: "rule a: invalid glob",
+		},
+		{
+			name:    "invalid severity",
+			content: "[[rules]]\nid = \"a\"\nregex = \"x\"\nmessage = \"m\"\nseverity = \"fatal\"\n",
+			wantErr: "rule a: invalid severity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.content))
+			if err == nil {
+				t.Fatalf("Parse() error = nil, want %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %q, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRule_Pattern(t *testing.T) {
+	if got := (Rule{Regex: "a+"}).Pattern(); got != "a+" {
+		t.Errorf("Pattern() = %q, want %q", got, "a+")
+	}
+	if got := (Rule{Fixed: "a+"}).Pattern(); got != `a\+` {
+		t.Errorf("Pattern() = %q, want %q", got, `a\+`)
+	}
+}
+
+func TestMatcher(t *testing.T) {
+	rules := []Rule{
+		{ID: "key", Regex: `AKIA[0-9A-Z]{4}`, Exclude: []string{"*_test.go"}},
+		{ID: "println", Fixed: "fmt.Println(", Include: []string{"*.go"}},
+		{ID: "todo", Regex: "todo", Include: []string{"src/**"}, Exclude: []string{"src/vendor"}},
+	}
+
+	m, err := NewMatcher(rules, true)
+	if err != nil {
+		t.Fatalf("NewMatcher() error = %v, want nil", err)
+	}
+
+	wantPatterns := []string{`AKIA[0-9A-Z]{4}`, `fmt\.Println\(`, "todo"}
+	if got := m.Patterns(); !reflect.DeepEqual(got, wantPatterns) {
+		t.Errorf("Patterns() = %v, want %v", got, wantPatterns)
+	}
+
+	tests := []struct {
+		name         string
+		relPath      string
+		matchedTexts []string
+		line         string
+		want         []string
+	}{
+		{
+			name:         "several rules",
+			relPath:      "main.go",
+			matchedTexts: []string{"fmt.Println(", "AKIAABCD"},
+			line:         `fmt.Println("AKIAABCD")`,
+			want:         []string{"key", "println"},
+		},
+		{
+			name:         "excluded file",
+			relPath:      "dir/main_test.go",
+			matchedTexts: []string{"fmt.Println(", "AKIAABCD"},
+			line:         `fmt.Println("AKIAABCD")`,
+			want:         []string{"println"},
+		},
+		{
+			name:         "not included file",
+			relPath:      "README.md",
+			matchedTexts: []string{"fmt.Println("},
+			line:         "fmt.Println(x)",
+			want:         nil,
+		},
+		{
+			name:         "ignore case",
+			relPath:      "src/app/main.go",
+			matchedTexts: []string{"TODO"},
+			line:         "// TODO",
+			want:         []string{"todo"},
+		},
+		{
+			name:         "excluded directory",
+			relPath:      "src/vendor/lib.go",
+			matchedTexts: []string{"TODO"},
+			line:         "// TODO",
+			want:         nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, rule := range m.Rules(tt.relPath, tt.matchedTexts, tt.line) {
+				got = append(got, rule.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Rules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.toml")
+	os.WriteFile(path, []byte("[[rules]]\nid = \"a\"\nregex = \"x\"\nmessage = \"m\"\n"), 0644)
+
+	rules, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+	if len(rules) != 1 || rules[0].ID != "a" {
+		t.Errorf("Load() = %+v, want rule a", rules)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "nonexistent.toml")); err == nil {
+		t.Error("Load() expected error for a missing file, got nil")
+	}
+}
@@ -5,16 +5,38 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/onozaty/reporg/internal/glob"
 )
 
 // Match represents a single search match result.
 type Match struct {
-	RelPath    string // Relative path from repository root
+	RelPath    string        // Relative path from repository root
+	LineNumber int           // Line number (1-indexed)
+	LineText   string        // The matched line content
+	Before     []ContextLine // Lines before the match (with BeforeContext)
+	After      []ContextLine // Lines after the match (with AfterContext)
+	Submatches []Submatch    // Matched parts of the line, in order
+}
+
+// Submatch is a part of a line matched by the pattern.
+// Columns count characters (runes), not bytes, so that they match editor columns.
+type Submatch struct {
+	Column    int    // Column of the first matched character (1-indexed)
+	EndColumn int    // Column just after the last matched character
+	Text      string // The matched text
+}
+
+// ContextLine is a line surrounding a match.
+type ContextLine struct {
 	LineNumber int    // Line number (1-indexed)
-	LineText   string // The matched line content
+	Text       string // The line content
 }
 
 // RipgrepMessage represents a single JSON message from ripgrep's --json output.
@@ -25,9 +47,18 @@ type RipgrepMessage struct {
 
 // MatchData represents the data field of a "match" type message from ripgrep.
 type MatchData struct {
-	Path       PathData `json:"path"`
-	Lines      TextData `json:"lines"`
-	LineNumber int      `json:"line_number"`
+	Path       PathData       `json:"path"`
+	Lines      TextData       `json:"lines"`
+	LineNumber int            `json:"line_number"`
+	Submatches []SubmatchData `json:"submatches"`
+}
+
+// SubmatchData represents a matched part of a line in ripgrep JSON output.
+// Start and End are byte offsets in the line.
+type SubmatchData struct {
+	Match TextData `json:"match"`
+	Start int      `json:"start"`
+	End   int      `json:"end"`
 }
 
 // PathData represents the path information in ripgrep JSON output.
@@ -49,16 +80,101 @@ type SearchOptions struct {
 	FixedStrings  bool     // Treat pattern as literal string, not regex (-F)
 	MaxLineLength int      // Maximum length of line text in output (0 = no limit)
 	Encoding      string   // Text encoding to use (--encoding, default: auto)
+	Paths         []string // Only search these files (slash-separated, relative to repository root; nil = all files)
+	BeforeContext int      // Number of lines to include before each match (-B)
+	AfterContext  int      // Number of lines to include after each match (-A)
+	NoRequireGit  bool     // Honor .gitignore files even if the searched directory is not a git repository (--no-require-git)
 }
 
 // SearchRepo executes ripgrep search on the given repository.
 // The onMatch callback is called for each match found.
 func SearchRepo(pattern, repoRoot string, opts SearchOptions, onMatch func(Match) error) error {
+	return SearchRepoPatterns([]string{pattern}, repoRoot, opts, onMatch)
+}
+
+// maxPathArgsLength is the maximum total length of the file paths passed to a single ripgrep run,
+// which keeps the command line under the Windows limit (32,767 characters).
+const maxPathArgsLength = 24 * 1024
+
+// SearchRepoPatterns executes ripgrep search for several patterns at once on the given repository.
+// A line matching any of the patterns is reported once, with the parts matched by each pattern
+// in its submatches. The onMatch callback is called for each match found.
+func SearchRepoPatterns(patterns []string, repoRoot string, opts SearchOptions, onMatch func(Match) error) error {
 	// Check if ripgrep is installed
 	if _, err := exec.LookPath("rg"); err != nil {
 		return fmt.Errorf("ripgrep not found: please install ripgrep from https://github.com/BurntSushi/ripgrep#installation")
 	}
 
+	if opts.Paths == nil {
+		return runRipgrep(patterns, repoRoot, []string{repoRoot}, nil, opts, onMatch)
+	}
+
+	// Search only the given files, passing them to ripgrep in chunks
+	paths, err := selectPaths(repoRoot, opts)
+	if err != nil {
+		return err
+	}
+	allowedPaths := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		allowedPaths[path] = true
+	}
+
+	var chunk []string
+	chunkLength := 0
+	for _, path := range paths {
+		target := filepath.Join(repoRoot, filepath.FromSlash(path))
+		if len(chunk) > 0 && chunkLength+len(target)+1 > maxPathArgsLength {
+			if err := runRipgrep(patterns, repoRoot, chunk, allowedPaths, opts, onMatch); err != nil {
+				return err
+			}
+			chunk, chunkLength = nil, 0
+		}
+		chunk = append(chunk, target)
+		chunkLength += len(target) + 1
+	}
+	if len(chunk) > 0 {
+		return runRipgrep(patterns, repoRoot, chunk, allowedPaths, opts, onMatch)
+	}
+	return nil
+}
+
+// selectPaths returns the files of opts.Paths that ripgrep would search when walking the repository.
+// ripgrep searches the files given on the command line regardless of the globs and of
+// whether they are hidden, so these are applied here.
+// Files that do not exist in the repository or are not regular files (e.g., submodules) are skipped.
+func selectPaths(repoRoot string, opts SearchOptions) ([]string, error) {
+	globs, err := glob.NewSet(opts.Globs)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, path := range opts.Paths {
+		if !globs.Selects(path) || (!opts.Hidden && isHidden(path)) {
+			continue
+		}
+		info, err := os.Lstat(filepath.Join(repoRoot, filepath.FromSlash(path)))
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// isHidden reports whether the file or any of its parent directories is hidden (starts with ".").
+func isHidden(relPath string) bool {
+	for _, name := range strings.Split(relPath, "/") {
+		if strings.HasPrefix(name, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// runRipgrep runs ripgrep on the targets (the repository root or files in it) and calls onMatch
+// for each match. Matches in files not in allowedPaths are skipped (nil = all files).
+func runRipgrep(patterns []string, repoRoot string, targets []string, allowedPaths map[string]bool, opts SearchOptions, onMatch func(Match) error) error {
 	// Build ripgrep arguments
 	args := []string{"--json"}
 
@@ -77,6 +193,11 @@ func SearchRepo(pattern, repoRoot string, opts SearchOptions, onMatch func(Match
 		args = append(args, "--hidden")
 	}
 
+	// Add no-require-git flag if requested
+	if opts.NoRequireGit {
+		args = append(args, "--no-require-git")
+	}
+
 	// Add fixed-strings flag if requested
 	if opts.FixedStrings {
 		args = append(args, "-F")
@@ -87,10 +208,23 @@ func SearchRepo(pattern, repoRoot string, opts SearchOptions, onMatch func(Match
 		args = append(args, "--encoding", opts.Encoding)
 	}
 
-	// Add pattern and path
-	args = append(args, pattern, repoRoot)
+	// Add context flags if requested
+	if opts.BeforeContext > 0 {
+		args = append(args, "-B", strconv.Itoa(opts.BeforeContext))
+	}
+	if opts.AfterContext > 0 {
+		args = append(args, "-A", strconv.Itoa(opts.AfterContext))
+	}
+	withContext := opts.BeforeContext > 0 || opts.AfterContext > 0
+
+	// Add patterns and paths
+	for _, pattern := range patterns {
+		args = append(args, "-e", pattern)
+	}
+	args = append(args, "--")
+	args = append(args, targets...)
 
-	// Execute: rg --json [options] <pattern> <repoRoot>
+	// Execute: rg --json [options] -e <pattern>... -- <repoRoot or files...>
 	cmd := exec.Command("rg", args...)
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -108,6 +242,34 @@ func SearchRepo(pattern, repoRoot string, opts SearchOptions, onMatch func(Match
 	buf := make([]byte, 0, 64*1024)
 	scanner.Buffer(buf, 10*1024*1024)
 
+	// With context, the matches of a file are held until all of its lines have been read,
+	// as the lines after a match are reported after it
+	var (
+		currentPath string
+		pending     []Match
+		fileLines   = make(map[int]string)
+	)
+	flush := func() error {
+		for _, match := range pending {
+			for n := match.LineNumber - opts.BeforeContext; n < match.LineNumber; n++ {
+				if text, ok := fileLines[n]; ok {
+					match.Before = append(match.Before, ContextLine{LineNumber: n, Text: text})
+				}
+			}
+			for n := match.LineNumber + 1; n <= match.LineNumber+opts.AfterContext; n++ {
+				if text, ok := fileLines[n]; ok {
+					match.After = append(match.After, ContextLine{LineNumber: n, Text: text})
+				}
+			}
+			if err := onMatch(match); err != nil {
+				return fmt.Errorf("callback error: %w", err)
+			}
+		}
+		pending = nil
+		fileLines = make(map[int]string)
+		return nil
+	}
+
 	// Process each line of JSON output
 	for scanner.Scan() {
 		line := scanner.Bytes()
@@ -117,11 +279,12 @@ func SearchRepo(pattern, repoRoot string, opts SearchOptions, onMatch func(Match
 			continue // Skip invalid JSON lines
 		}
 
-		// Only process "match" type messages
-		if msg.Type != "match" {
+		// Only process "match" type messages (and "context" type messages with context)
+		if msg.Type != "match" && !(withContext && msg.Type == "context") {
 			continue
 		}
 
+		// Context messages have the same structure as match messages
 		var matchData MatchData
 		if err := json.Unmarshal(msg.Data, &matchData); err != nil {
 			continue // Skip if we can't parse match data
@@ -139,41 +302,53 @@ func SearchRepo(pattern, repoRoot string, opts SearchOptions, onMatch func(Match
 			relPath = absPath // Fall back to absolute path if conversion fails
 		}
 
-		// Extract line text
-		// ripgrep uses "text" field for UTF-8 content and "bytes" field for non-UTF-8 content.
-		// When using --encoding with non-UTF-8 encodings, ripgrep may output base64-encoded bytes.
-		lineText := ""
-		if matchData.Lines.Text != nil {
-			// UTF-8 text content
-			lineText = *matchData.Lines.Text
-		} else if matchData.Lines.Bytes != nil {
-			// Base64-encoded bytes (for non-UTF-8 content)
-			// Decode base64 to get the original bytes, then convert to string
-			if decoded, err := base64.StdEncoding.DecodeString(*matchData.Lines.Bytes); err == nil {
-				lineText = string(decoded)
-			}
+		// Skip files that are not in the allowed paths (as a safety net)
+		if allowedPaths != nil && !allowedPaths[filepath.ToSlash(relPath)] {
+			continue
 		}
 
-		// Remove trailing newline characters (LF, CRLF, CR)
-		lineText = strings.TrimRight(lineText, "\r\n")
+		rawText := decodeText(matchData.Lines)
+		lineText := trimLineText(rawText, opts.MaxLineLength)
+		submatches := convertSubmatches(rawText, matchData.Submatches)
 
-		// Truncate line text if MaxLineLength is specified and line exceeds the limit
-		if opts.MaxLineLength > 0 && len(lineText) > opts.MaxLineLength {
-			lineText = lineText[:opts.MaxLineLength] + "..."
+		if !withContext {
+			match := Match{
+				RelPath:    relPath,
+				LineNumber: matchData.LineNumber,
+				LineText:   lineText,
+				Submatches: submatches,
+			}
+
+			// Call the callback
+			if err := onMatch(match); err != nil {
+				return fmt.Errorf("callback error: %w", err)
+			}
+			continue
 		}
 
-		match := Match{
-			RelPath:    relPath,
-			LineNumber: matchData.LineNumber,
-			LineText:   lineText,
+		if relPath != currentPath {
+			if err := flush(); err != nil {
+				return err
+			}
+			currentPath = relPath
 		}
 
-		// Call the callback
-		if err := onMatch(match); err != nil {
-			return fmt.Errorf("callback error: %w", err)
+		// Matched lines are also context of nearby matches
+		fileLines[matchData.LineNumber] = lineText
+		if msg.Type == "match" {
+			pending = append(pending, Match{
+				RelPath:    relPath,
+				LineNumber: matchData.LineNumber,
+				LineText:   lineText,
+				Submatches: submatches,
+			})
 		}
 	}
 
+	if err := flush(); err != nil {
+		return err
+	}
+
 	if err := scanner.Err(); err != nil {
 		return fmt.Errorf("error reading ripgrep output: %w", err)
 	}
@@ -188,3 +363,53 @@ func SearchRepo(pattern, repoRoot string, opts SearchOptions, onMatch func(Match
 
 	return nil
 }
+
+// decodeText returns the content of ripgrep text data.
+func decodeText(data TextData) string {
+	if data.Text != nil {
+		// UTF-8 text content
+		return *data.Text
+	}
+	if data.Bytes != nil {
+		// Base64-encoded bytes (for non-UTF-8 content)
+		// Decode base64 to get the original bytes, then convert to string
+		if decoded, err := base64.StdEncoding.DecodeString(*data.Bytes); err == nil {
+			return string(decoded)
+		}
+	}
+	return ""
+}
+
+// trimLineText removes the trailing newline from a line,
+// and truncates it to maxLineLength if it is greater than 0.
+func trimLineText(lineText string, maxLineLength int) string {
+	// Remove trailing newline characters (LF, CRLF, CR)
+	lineText = strings.TrimRight(lineText, "\r\n")
+
+	// Truncate line text if MaxLineLength is specified and line exceeds the limit
+	if maxLineLength > 0 && len(lineText) > maxLineLength {
+		lineText = lineText[:maxLineLength] + "..."
+	}
+
+	return lineText
+}
+
+// convertSubmatches converts the byte offsets of ripgrep submatches in the line
+// into rune-based columns. Submatches with offsets outside the line are skipped.
+func convertSubmatches(lineText string, data []SubmatchData) []Submatch {
+	var submatches []Submatch
+	for _, sub := range data {
+		if sub.Start < 0 || sub.End < sub.Start || sub.End > len(lineText) {
+			continue
+		}
+
+		column := utf8.RuneCountInString(lineText[:sub.Start]) + 1
+		text := lineText[sub.Start:sub.End]
+		submatches = append(submatches, Submatch{
+			Column:    column,
+			EndColumn: column + utf8.RuneCountInString(text),
+			Text:      text,
+		})
+	}
+	return submatches
+}
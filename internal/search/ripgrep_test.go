@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -556,6 +557,111 @@ func TestSearchRepo_Glob_Exclude(t *testing.T) {
 	}
 }
 
+func TestSearchRepo_Paths(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	os.MkdirAll(filepath.Join(tmpDir, "src"), 0755)
+	for _, name := range []string{"changed.go", "src/changed.go", "unchanged.go"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, filepath.FromSlash(name)), []byte("package main\n"), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+	}
+
+	matches, err := collectMatches("package", tmpDir, SearchOptions{
+		Paths: []string{"changed.go", "src/changed.go"},
+	})
+	if err != nil {
+		t.Fatalf("SearchRepo() error = %v, want nil", err)
+	}
+
+	if len(matches) != 2 {
+		t.Errorf("Paths search found %d matches, want 2", len(matches))
+	}
+
+	for _, match := range matches {
+		if strings.Contains(match.RelPath, "unchanged.go") {
+			t.Errorf("Expected to exclude files not in Paths, but got %s", match.RelPath)
+		}
+	}
+
+	// An empty (non-nil) list excludes all files
+	matches, err = collectMatches("package", tmpDir, SearchOptions{Paths: []string{}})
+	if err != nil {
+		t.Fatalf("SearchRepo() error = %v, want nil", err)
+	}
+	if len(matches) != 0 {
+		t.Errorf("Empty Paths search found %d matches, want 0", len(matches))
+	}
+}
+
+func TestSearchRepo_Paths_Filtered(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	os.MkdirAll(filepath.Join(tmpDir, ".github"), 0755)
+	os.MkdirAll(filepath.Join(tmpDir, "sub"), 0755)
+	for _, name := range []string{"main.go", "main_test.go", "README.md", ".github/ci.go", "sub/lib.go"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, filepath.FromSlash(name)), []byte("package main\n"), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+	}
+
+	// Files given to ripgrep are filtered as if it walked the repository;
+	// deleted files and directories (e.g., submodules) in the list are skipped
+	paths := []string{"main.go", "main_test.go", "README.md", ".github/ci.go", "deleted.go", "sub"}
+
+	tests := []struct {
+		name string
+		opts SearchOptions
+		want []string
+	}{
+		{name: "all", opts: SearchOptions{}, want: []string{"README.md", "main.go", "main_test.go"}},
+		{name: "globs", opts: SearchOptions{Globs: []string{"*.go", "!*_test.go"}}, want: []string{"main.go"}},
+		{name: "hidden", opts: SearchOptions{Hidden: true, Globs: []string{"*.go"}}, want: []string{".github/ci.go", "main.go", "main_test.go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			opts.Paths = paths
+			matches, err := collectMatches("package", tmpDir, opts)
+			if err != nil {
+				t.Fatalf("SearchRepo() error = %v, want nil", err)
+			}
+
+			var got []string
+			for _, match := range matches {
+				got = append(got, filepath.ToSlash(match.RelPath))
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SearchRepo() files = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchRepo_Paths_Chunked(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Enough files for the paths to be passed to ripgrep in several runs
+	var paths []string
+	for i := 0; i < 1000; i++ {
+		name := fmt.Sprintf("file_with_a_rather_long_name_%04d.txt", i)
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte("pattern\n"), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+		paths = append(paths, name)
+	}
+
+	matches, err := collectMatches("pattern", tmpDir, SearchOptions{Paths: paths})
+	if err != nil {
+		t.Fatalf("SearchRepo() error = %v, want nil", err)
+	}
+	if len(matches) != len(paths) {
+		t.Errorf("Chunked Paths search found %d matches, want %d", len(matches), len(paths))
+	}
+}
+
 func TestSearchRepo_Hidden(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -583,6 +689,33 @@ func TestSearchRepo_Hidden(t *testing.T) {
 	}
 }
 
+func TestSearchRepo_NoRequireGit(t *testing.T) {
+	// A directory that is not a git repository (e.g., an extracted revision)
+	tmpDir := t.TempDir()
+
+	os.WriteFile(filepath.Join(tmpDir, ".gitignore"), []byte("ignored.txt\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "ignored.txt"), []byte("secret data\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "kept.txt"), []byte("secret data\n"), 0644)
+
+	// Without --no-require-git, .gitignore is not applied outside a git repository
+	matches, err := collectMatches("secret", tmpDir, SearchOptions{})
+	if err != nil {
+		t.Fatalf("SearchRepo() error = %v, want nil", err)
+	}
+	if len(matches) != 2 {
+		t.Errorf("Search without --no-require-git found %d matches, want 2", len(matches))
+	}
+
+	// With --no-require-git
+	matches, err = collectMatches("secret", tmpDir, SearchOptions{NoRequireGit: true})
+	if err != nil {
+		t.Fatalf("SearchRepo() error = %v, want nil", err)
+	}
+	if len(matches) != 1 || matches[0].RelPath != "kept.txt" {
+		t.Errorf("Search with --no-require-git = %+v, want only kept.txt", matches)
+	}
+}
+
 func TestSearchRepo_FixedStrings(t *testing.T) {
 	tmpDir := t.TempDir()
 	testFile := filepath.Join(tmpDir, "test.txt")
@@ -727,6 +860,193 @@ func TestSearchRepo_MaxLineLength(t *testing.T) {
 	}
 }
 
+func TestSearchRepo_Context(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	content := "line1\nline2\nTODO: a\nline4\nTODO: b\nline6\nline7\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "a.txt"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "b.txt"), []byte("TODO: c\nlast\n"), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	formatLines := func(lines []ContextLine) string {
+		var parts []string
+		for _, l := range lines {
+			parts = append(parts, fmt.Sprintf("%d:%s", l.LineNumber, l.Text))
+		}
+		return strings.Join(parts, ",")
+	}
+
+	tests := []struct {
+		name       string
+		opts       SearchOptions
+		wantBefore map[string]string
+		wantAfter  map[string]string
+	}{
+		{
+			name:       "no context",
+			opts:       SearchOptions{},
+			wantBefore: map[string]string{"a.txt:3": "", "a.txt:5": "", "b.txt:1": ""},
+			wantAfter:  map[string]string{"a.txt:3": "", "a.txt:5": "", "b.txt:1": ""},
+		},
+		{
+			name:       "before",
+			opts:       SearchOptions{BeforeContext: 2},
+			wantBefore: map[string]string{"a.txt:3": "1:line1,2:line2", "a.txt:5": "3:TODO: a,4:line4", "b.txt:1": ""},
+			wantAfter:  map[string]string{"a.txt:3": "", "a.txt:5": "", "b.txt:1": ""},
+		},
+		{
+			name:       "after",
+			opts:       SearchOptions{AfterContext: 1},
+			wantBefore: map[string]string{"a.txt:3": "", "a.txt:5": "", "b.txt:1": ""},
+			wantAfter:  map[string]string{"a.txt:3": "4:line4", "a.txt:5": "6:line6", "b.txt:1": "2:last"},
+		},
+		{
+			name:       "both",
+			opts:       SearchOptions{BeforeContext: 1, AfterContext: 2},
+			wantBefore: map[string]string{"a.txt:3": "2:line2", "a.txt:5": "4:line4", "b.txt:1": ""},
+			wantAfter:  map[string]string{"a.txt:3": "4:line4,5:TODO: b", "a.txt:5": "6:line6,7:line7", "b.txt:1": "2:last"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := collectMatches("TODO", tmpDir, tt.opts)
+			if err != nil {
+				t.Fatalf("SearchRepo() error = %v, want nil", err)
+			}
+			if len(matches) != 3 {
+				t.Fatalf("SearchRepo() returned %d matches, want 3", len(matches))
+			}
+
+			for _, match := range matches {
+				key := fmt.Sprintf("%s:%d", filepath.ToSlash(match.RelPath), match.LineNumber)
+				if got := formatLines(match.Before); got != tt.wantBefore[key] {
+					t.Errorf("%s Before = %q, want %q", key, got, tt.wantBefore[key])
+				}
+				if got := formatLines(match.After); got != tt.wantAfter[key] {
+					t.Errorf("%s After = %q, want %q", key, got, tt.wantAfter[key])
+				}
+			}
+		})
+	}
+}
+
+func TestSearchRepo_Submatches(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	content := "// 日本語 TODO: fix TODO\nno match\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "a.txt"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	matches, err := collectMatches("TODO", tmpDir, SearchOptions{MaxLineLength: 5})
+	if err != nil {
+		t.Fatalf("SearchRepo() error = %v, want nil", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("SearchRepo() returned %d matches, want 1", len(matches))
+	}
+
+	// Columns are counted in characters of the whole line, even if the line text is truncated
+	want := []Submatch{
+		{Column: 8, EndColumn: 12, Text: "TODO"},
+		{Column: 18, EndColumn: 22, Text: "TODO"},
+	}
+	got := matches[0].Submatches
+	if len(got) != len(want) {
+		t.Fatalf("Submatches = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Submatches[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSearchRepoPatterns(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	content := "oldAPI()\nlegacyCall()\noldAPI(legacyCall())\n-flag\nother\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "a.txt"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	var matches []Match
+	err := SearchRepoPatterns([]string{"oldAPI", "legacy[A-Z]", "-flag"}, tmpDir, SearchOptions{}, func(match Match) error {
+		matches = append(matches, match)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SearchRepoPatterns() error = %v, want nil", err)
+	}
+
+	// Each line is reported once, with the parts matched by any pattern
+	want := map[int]string{1: "oldAPI", 2: "legacyC", 3: "oldAPI,legacyC", 4: "-flag"}
+	if len(matches) != len(want) {
+		t.Fatalf("SearchRepoPatterns() returned %d matches, want %d", len(matches), len(want))
+	}
+	for _, match := range matches {
+		var texts []string
+		for _, sub := range match.Submatches {
+			texts = append(texts, sub.Text)
+		}
+		if got := strings.Join(texts, ","); got != want[match.LineNumber] {
+			t.Errorf("Line %d submatches = %v, want %v", match.LineNumber, got, want[match.LineNumber])
+		}
+	}
+}
+
+func TestConvertSubmatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		lineText string
+		data     []SubmatchData
+		want     []Submatch
+	}{
+		{
+			name:     "ascii",
+			lineText: "foo bar\n",
+			data:     []SubmatchData{{Start: 4, End: 7}},
+			want:     []Submatch{{Column: 5, EndColumn: 8, Text: "bar"}},
+		},
+		{
+			name:     "multibyte",
+			lineText: "日本語テキスト",
+			data:     []SubmatchData{{Start: 9, End: 21}},
+			want:     []Submatch{{Column: 4, EndColumn: 8, Text: "テキスト"}},
+		},
+		{
+			name:     "multiple",
+			lineText: "a-a",
+			data:     []SubmatchData{{Start: 0, End: 1}, {Start: 2, End: 3}},
+			want:     []Submatch{{Column: 1, EndColumn: 2, Text: "a"}, {Column: 3, EndColumn: 4, Text: "a"}},
+		},
+		{
+			name:     "out of range",
+			lineText: "abc",
+			data:     []SubmatchData{{Start: 2, End: 10}, {Start: 2, End: 1}},
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convertSubmatches(tt.lineText, tt.data)
+			if len(got) != len(tt.want) {
+				t.Fatalf("convertSubmatches() = %+v, want %+v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("convertSubmatches()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
 func TestSearchRepo_Encoding(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -1,11 +1,23 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
+	"io"
 	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/onozaty/reporg/internal/codeowners"
+	"github.com/onozaty/reporg/internal/config"
 	"github.com/onozaty/reporg/internal/git"
+	"github.com/onozaty/reporg/internal/history"
 	"github.com/onozaty/reporg/internal/output"
+	"github.com/onozaty/reporg/internal/patterns"
+	"github.com/onozaty/reporg/internal/rules"
 	"github.com/onozaty/reporg/internal/search"
 	"github.com/spf13/cobra"
 )
@@ -17,12 +29,51 @@ var (
 
 // RepoContext contains information about a Git repository needed for generating URLs.
 type RepoContext struct {
-	Root   string // Absolute path to repository root
-	Owner  string // GitHub owner
-	Repo   string // Repository name
-	Branch string // Branch name for URLs
+	Root     string           // Absolute path to repository root
+	Remote   string           // Name of the remote used for URL generation
+	Provider git.Provider     // Hosting provider used for URL generation
+	Identity git.RepoIdentity // Repository identity on the hosting provider
+	Branch   string           // Current branch name (empty if detached HEAD)
+	Commit   string           // Full commit SHA of HEAD (or of Rev when searching a revision)
+	Rev      string           // Revision searched instead of the working tree (empty = working tree)
+	Ref      string           // Ref used in URLs (branch name or commit SHA)
 }
 
+// repoContextOptions contains options that control how RepoContext is resolved.
+type repoContextOptions struct {
+	Remotes   []string // Remote names in order of preference (empty = origin, then any remote)
+	Permalink bool     // Use the HEAD commit SHA in URLs
+	Ref       string   // Ref to use in URLs, overriding branch detection (empty = auto)
+	Rev       string   // Revision to search instead of the working tree (empty = working tree)
+}
+
+const (
+	// trackingRemote is the special remote name that selects the remote tracked by the current branch.
+	trackingRemote = "@upstream"
+	// defaultBranchRef is the special ref that selects the default branch of the remote.
+	defaultBranchRef = "@default"
+)
+
+// annotationOptions controls the optional information added to each match.
+type annotationOptions struct {
+	Blame      bool              // Add git blame columns
+	Codeowners bool              // Add the CODEOWNERS owners column
+	Owners     []string          // Only output matches in files owned by any of these owners (empty = all)
+	Patterns   *patterns.Matcher // Add the pattern column with the patterns that matched (nil = single pattern)
+}
+
+// Values for the --stale option.
+const (
+	staleWarn = "warn"
+	staleDrop = "drop"
+)
+
+// Values for the --worktrees option.
+const (
+	worktreesMerge = "merge"
+	worktreesLabel = "label"
+)
+
 var rootCmd = newRootCmd()
 
 func newRootCmd() *cobra.Command {
@@ -35,9 +86,9 @@ func newRootCmd() *cobra.Command {
 		Use:   "reporg <pattern> <repoRoot1> [repoRoot2...]",
 		Short: "Search git repositories with ripgrep and generate shareable references",
 		Long: `reporg searches Git repositories using ripgrep and outputs results in TSV format.
-Each result includes the local file path, matched line content, and GitHub URL reference.`,
+Each result includes the local file path, matched line content, and GitHub/GitLab URL reference.`,
 		Version: versionInfo,
-		Args:    cobra.MinimumNArgs(2),
+		Args:    cobra.ArbitraryArgs,
 		RunE:    run,
 	}
 
@@ -46,8 +97,40 @@ Each result includes the local file path, matched line content, and GitHub URL r
 	cmd.Flags().StringSliceP("glob", "g", nil, "Include or exclude files matching glob pattern (can be specified multiple times)")
 	cmd.Flags().Bool("hidden", false, "Search hidden files and directories")
 	cmd.Flags().BoolP("fixed-strings", "F", false, "Treat pattern as literal string, not regex")
+	cmd.Flags().StringArrayP("regexp", "e", nil, "Pattern to search for, adding a pattern column with the patterns that matched (can be specified multiple times). All arguments are then repository paths")
+	cmd.Flags().StringP("file", "f", "", "File with patterns to search for, one per line, optionally named as 'name<TAB>pattern'. Adds a pattern column like -e, and can be combined with it")
+	cmd.Flags().String("rules", "", "Rule file (TOML) of named checks to run instead of a pattern, adding rule_id, severity, message and fix columns. All arguments are then repository paths")
+	cmd.Flags().IntP("after-context", "A", 0, "Show NUM lines after each match in a context column, and link to the line range")
+	cmd.Flags().IntP("before-context", "B", 0, "Show NUM lines before each match in a context column, and link to the line range")
+	cmd.Flags().IntP("context", "C", 0, "Show NUM lines before and after each match (overridden by -A and -B)")
+	cmd.Flags().Bool("column", false, "Add the column of the first match to local_path (path:line:column) and a matched_text column with the matched text")
 	cmd.Flags().IntP("max-line-length", "m", 0, "Maximum line length in output (0 = no limit). Lines longer than this will be truncated with '...'")
 	cmd.Flags().StringP("encoding", "E", "auto", "Text encoding to use for reading files (e.g., utf-8, shift_jis, euc-jp, iso-2022-jp). Default: auto (UTF-8/UTF-16 BOM detection)")
+	cmd.Flags().Bool("permalink", false, "Use the commit SHA instead of the branch name in URLs (always used when HEAD is detached)")
+	cmd.Flags().String("config", "", "Configuration file path (JSON) declaring additional hosts")
+	cmd.Flags().StringArray("host", nil, "Additional host in TYPE:HOST[=BASE_URL] format, e.g., github:ghe.example.com (can be specified multiple times)")
+	cmd.Flags().String("ref", "", "Ref (branch, tag or commit) to use in URLs. Use '"+defaultBranchRef+"' for the default branch of the remote")
+	cmd.Flags().Bool("link-status", false, "Add a link_status column showing whether the local file matches the linked revision (clean, modified, untracked, unpushed)")
+	cmd.Flags().String("stale", "", "How to handle matches whose link may be stale (not clean): 'warn' to print a warning, 'drop' to omit them")
+	cmd.Flags().String("rev", "", "Search the tree of the given revision (branch, tag or commit) instead of the working tree. URLs use its tag or branch name (the commit SHA for other revisions or with --permalink)")
+	cmd.Flags().Bool("history", false, "Search the commit history for lines added or removed that match the pattern (git log -G, or -S with -F) instead of the working tree")
+	cmd.Flags().StringSlice("branches", nil, "Search the local and remote-tracking branches matching the pattern (e.g., 'release/*') instead of the working tree, adding a ref column (can be specified multiple times)")
+	cmd.Flags().Bool("blame", false, "Add author, author_email, commit and commit_date columns from git blame for each matched line")
+	cmd.Flags().Bool("codeowners", false, "Add an owners column with the owners of each matched file from the CODEOWNERS file")
+	cmd.Flags().StringSlice("owner", nil, "Only output matches in files owned by the given owner in CODEOWNERS (e.g., @org/team; can be specified multiple times)")
+	cmd.Flags().String("changed-since", "", "Only search files changed since the merge base of the given ref and HEAD (e.g., origin/main)")
+	cmd.Flags().Bool("include-uncommitted", false, "With --changed-since, also include staged and unstaged changes")
+	cmd.Flags().Bool("added-lines-only", false, "With --changed-since, only report matches on lines added or modified by the changes")
+	cmd.Flags().String("worktrees", "", "How to handle worktrees of the same repository: 'merge' to search only the first given path of each repository, 'label' to add worktree and branch columns")
+	cmd.Flags().Bool("no-submodules", false, "Do not search checked-out submodules (by default, matches in submodules link to the submodule repository)")
+	cmd.Flags().String("repos-file", "", "File listing repository paths, one per line ('-' for stdin). Blank lines and lines starting with '#' are ignored")
+	cmd.Flags().Bool("discover", false, "Treat the paths as parent directories and search every repository found under them")
+	cmd.Flags().Int("max-depth", 3, "With --discover, maximum depth of repositories below each parent directory")
+	cmd.Flags().StringSlice("exclude-dir", []string{"node_modules"}, "With --discover, directory names (glob patterns) not to descend into")
+	cmd.Flags().StringSlice("include-repo", nil, "Only search repositories matching the pattern: 'name' matches the repository name, 'owner/name' the full name (e.g., 'myorg/*'; can be specified multiple times)")
+	cmd.Flags().StringSlice("exclude-repo", nil, "Skip repositories matching the pattern, in the same format as --include-repo (can be specified multiple times)")
+	cmd.Flags().Bool("tracked-only", false, "Only search files tracked by git (untracked files and files outside a sparse checkout are skipped)")
+	cmd.Flags().StringSlice("remote", nil, "Remote(s) to link to, in order of preference (e.g., upstream,origin). Use '"+trackingRemote+"' for the remote tracked by the current branch (default: origin, then the first supported remote)")
 
 	return cmd
 }
@@ -59,9 +142,6 @@ func main() {
 }
 
 func run(cmd *cobra.Command, args []string) error {
-	pattern := args[0]
-	repoPaths := args[1:]
-
 	// Get flags
 	outputFile, _ := cmd.Flags().GetString("output")
 	ignoreCase, _ := cmd.Flags().GetBool("ignore-case")
@@ -69,13 +149,186 @@ func run(cmd *cobra.Command, args []string) error {
 	hidden, _ := cmd.Flags().GetBool("hidden")
 	fixedStrings, _ := cmd.Flags().GetBool("fixed-strings")
 	maxLineLength, _ := cmd.Flags().GetInt("max-line-length")
+	afterContext, _ := cmd.Flags().GetInt("after-context")
+	beforeContext, _ := cmd.Flags().GetInt("before-context")
+	contextLines, _ := cmd.Flags().GetInt("context")
+	showColumn, _ := cmd.Flags().GetBool("column")
 	encoding, _ := cmd.Flags().GetString("encoding")
+	permalink, _ := cmd.Flags().GetBool("permalink")
+	configFile, _ := cmd.Flags().GetString("config")
+	hosts, _ := cmd.Flags().GetStringArray("host")
+	remotes, _ := cmd.Flags().GetStringSlice("remote")
+	ref, _ := cmd.Flags().GetString("ref")
+	linkStatus, _ := cmd.Flags().GetBool("link-status")
+	stale, _ := cmd.Flags().GetString("stale")
+	rev, _ := cmd.Flags().GetString("rev")
+	historyMode, _ := cmd.Flags().GetBool("history")
+	branchPatterns, _ := cmd.Flags().GetStringSlice("branches")
+	blame, _ := cmd.Flags().GetBool("blame")
+	showOwners, _ := cmd.Flags().GetBool("codeowners")
+	ownerFilter, _ := cmd.Flags().GetStringSlice("owner")
+	changedSince, _ := cmd.Flags().GetString("changed-since")
+	includeUncommitted, _ := cmd.Flags().GetBool("include-uncommitted")
+	addedLinesOnly, _ := cmd.Flags().GetBool("added-lines-only")
+	trackedOnly, _ := cmd.Flags().GetBool("tracked-only")
+	worktrees, _ := cmd.Flags().GetString("worktrees")
+	noSubmodules, _ := cmd.Flags().GetBool("no-submodules")
+	reposFile, _ := cmd.Flags().GetString("repos-file")
+	discover, _ := cmd.Flags().GetBool("discover")
+	maxDepth, _ := cmd.Flags().GetInt("max-depth")
+	excludeDirs, _ := cmd.Flags().GetStringSlice("exclude-dir")
+	includeRepos, _ := cmd.Flags().GetStringSlice("include-repo")
+	excludeRepos, _ := cmd.Flags().GetStringSlice("exclude-repo")
+	regexps, _ := cmd.Flags().GetStringArray("regexp")
+	patternFile, _ := cmd.Flags().GetString("file")
+	rulesFile, _ := cmd.Flags().GetString("rules")
+
+	// Patterns given with -e or -f are named in the pattern column,
+	// and all arguments are then repository paths
+	var searchPatterns []patterns.Pattern
+	for _, regex := range regexps {
+		searchPatterns = append(searchPatterns, patterns.Pattern{Name: regex, Regex: regex})
+	}
+	if patternFile != "" {
+		loaded, err := patterns.Load(patternFile)
+		if err != nil {
+			return err
+		}
+		if len(loaded) == 0 {
+			return fmt.Errorf("no patterns found in %s", patternFile)
+		}
+		searchPatterns = append(searchPatterns, loaded...)
+	}
+	showPattern := len(searchPatterns) > 0
+
+	// Rules are named checks, searched for at once and attributed by their patterns and globs
+	var ruleMatcher *rules.Matcher
+	if rulesFile != "" {
+		if showPattern || fixedStrings {
+			return fmt.Errorf("--rules cannot be combined with -e, -f or -F")
+		}
+		loaded, err := rules.Load(rulesFile)
+		if err != nil {
+			return err
+		}
+		if len(loaded) == 0 {
+			return fmt.Errorf("no rules found in %s", rulesFile)
+		}
+		ruleMatcher, err = rules.NewMatcher(loaded, ignoreCase)
+		if err != nil {
+			return err
+		}
+	}
+
+	repoPaths := args
+	if !showPattern && rulesFile == "" {
+		if len(args) == 0 {
+			return fmt.Errorf("no pattern specified (pass it as the first argument, or with -e or -f)")
+		}
+		searchPatterns = []patterns.Pattern{{Name: args[0], Regex: args[0]}}
+		repoPaths = args[1:]
+	}
+	var regexes []string
+	for _, p := range searchPatterns {
+		regexes = append(regexes, p.Regex)
+	}
+
+	if !cmd.Flags().Changed("after-context") {
+		afterContext = contextLines
+	}
+	if !cmd.Flags().Changed("before-context") {
+		beforeContext = contextLines
+	}
+	if afterContext < 0 || beforeContext < 0 {
+		return fmt.Errorf("invalid context value: must be 0 or greater")
+	}
+	showContext := afterContext > 0 || beforeContext > 0
+	if (showContext || showColumn) && historyMode {
+		return fmt.Errorf("-A, -B, -C and --column cannot be combined with --history")
+	}
+	if showPattern && historyMode {
+		return fmt.Errorf("-e and -f cannot be combined with --history")
+	}
+	if rulesFile != "" && (historyMode || len(branchPatterns) > 0) {
+		return fmt.Errorf("--rules cannot be combined with --history or --branches")
+	}
+
+	if stale != "" && stale != staleWarn && stale != staleDrop {
+		return fmt.Errorf("invalid --stale value: %s (must be '%s' or '%s')", stale, staleWarn, staleDrop)
+	}
+	if historyMode && (linkStatus || stale != "" || blame) {
+		return fmt.Errorf("--history cannot be combined with --link-status, --stale or --blame")
+	}
+	if changedSince == "" && (includeUncommitted || addedLinesOnly) {
+		return fmt.Errorf("--include-uncommitted and --added-lines-only require --changed-since")
+	}
+	if changedSince != "" && (historyMode || len(branchPatterns) > 0) {
+		return fmt.Errorf("--changed-since cannot be combined with --history or --branches")
+	}
+	if includeUncommitted && rev != "" {
+		return fmt.Errorf("--include-uncommitted cannot be combined with --rev")
+	}
+	if worktrees != "" && worktrees != worktreesMerge && worktrees != worktreesLabel {
+		return fmt.Errorf("invalid --worktrees value: %s (must be '%s' or '%s')", worktrees, worktreesMerge, worktreesLabel)
+	}
+	if worktrees == worktreesLabel && (historyMode || len(branchPatterns) > 0 || rev != "") {
+		return fmt.Errorf("--worktrees %s cannot be combined with --history, --branches or --rev", worktreesLabel)
+	}
+	if !discover && (cmd.Flags().Changed("max-depth") || cmd.Flags().Changed("exclude-dir")) {
+		return fmt.Errorf("--max-depth and --exclude-dir require --discover")
+	}
+	if maxDepth < 0 {
+		return fmt.Errorf("invalid --max-depth value: %d (must be 0 or greater)", maxDepth)
+	}
+	for _, p := range append(slices.Clone(includeRepos), excludeRepos...) {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid repository pattern: %s", p)
+		}
+	}
+	if len(branchPatterns) > 0 && (historyMode || rev != "" || ref != "" || linkStatus || stale != "") {
+		return fmt.Errorf("--branches cannot be combined with --history, --rev, --ref, --link-status or --stale")
+	}
+
+	// Hosting providers used to resolve remote URLs
+	registry, err := buildRegistry(configFile, hosts)
+	if err != nil {
+		return err
+	}
+
+	// Add repository paths listed in the file
+	if reposFile != "" {
+		listed, err := readReposFile(reposFile, cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		repoPaths = append(repoPaths, listed...)
+	}
+	if len(repoPaths) == 0 {
+		return fmt.Errorf("no repository paths specified (pass them as arguments or with --repos-file)")
+	}
+
+	// Find repositories under the parent directories
+	if discover {
+		repoPaths, err = git.DiscoverRepos(repoPaths, git.DiscoverOptions{
+			MaxDepth:    maxDepth,
+			ExcludeDirs: excludeDirs,
+		})
+		if err != nil {
+			return err
+		}
+	}
 
 	// Validate and deduplicate repository paths
 	uniqueRepos, err := git.DeduplicateRepoPaths(repoPaths)
 	if err != nil {
 		return fmt.Errorf("repository validation failed: %w", err)
 	}
+	if worktrees == worktreesMerge {
+		uniqueRepos, err = git.MergeWorktrees(uniqueRepos)
+		if err != nil {
+			return fmt.Errorf("failed to merge worktrees: %w", err)
+		}
+	}
 
 	// Determine output destination
 	writer := os.Stdout
@@ -88,18 +341,102 @@ func run(cmd *cobra.Command, args []string) error {
 		writer = file
 	}
 
-	// Create TSV writer
-	tsvWriter := output.NewTSVWriter(writer)
+	// Create TSV writer with optional columns
+	var columns []output.Column
+	if historyMode {
+		columns = append(columns, output.ColumnCommit, output.ColumnAuthor, output.ColumnDate, output.ColumnChange)
+	}
+	if len(branchPatterns) > 0 {
+		columns = append(columns, output.ColumnRef)
+	}
+	if worktrees == worktreesLabel {
+		columns = append(columns, output.ColumnWorktree, output.ColumnBranch)
+	}
+	if blame {
+		columns = append(columns, output.ColumnAuthor, output.ColumnAuthorEmail, output.ColumnCommit, output.ColumnCommitDate)
+	}
+	if showOwners {
+		columns = append(columns, output.ColumnOwners)
+	}
+	if linkStatus {
+		columns = append(columns, output.ColumnLinkStatus)
+	}
+	if showColumn {
+		columns = append(columns, output.ColumnMatchedText)
+	}
+	if showContext {
+		columns = append(columns, output.ColumnContext)
+	}
+	if showPattern {
+		columns = append(columns, output.ColumnPattern)
+	}
+	if rulesFile != "" {
+		columns = append(columns, output.ColumnRuleID, output.ColumnSeverity, output.ColumnMessage, output.ColumnFix)
+	}
+	tsvWriter := output.NewTSVWriter(writer, columns...)
+
+	ctxOpts := repoContextOptions{
+		Remotes:   remotes,
+		Permalink: permalink,
+		Ref:       ref,
+		Rev:       rev,
+	}
+
+	annotations := annotationOptions{
+		Blame:      blame,
+		Codeowners: showOwners,
+		Owners:     ownerFilter,
+	}
+	if showPattern {
+		annotations.Patterns, err = patterns.NewMatcher(searchPatterns, ignoreCase, fixedStrings)
+		if err != nil {
+			return err
+		}
+	}
 
 	// Process each repository
 	for _, repoRoot := range uniqueRepos {
 		// Get repository context
-		repoCtx, err := getRepoContext(repoRoot)
+		repoCtx, err := getRepoContext(repoRoot, registry, ctxOpts)
 		if err != nil {
+			// Discovered repositories may include local-only ones, which should not stop the search
+			if discover {
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: skipping %s: %v\n", repoRoot, err)
+				continue
+			}
 			return fmt.Errorf("failed to get repository context for %s: %w", repoRoot, err)
 		}
 
-		repository := fmt.Sprintf("%s/%s", repoCtx.Owner, repoCtx.Repo)
+		if !repoSelected(repoCtx.Identity, includeRepos, excludeRepos) {
+			continue
+		}
+
+		if historyMode {
+			historyOpts := history.SearchOptions{
+				IgnoreCase:    ignoreCase,
+				Globs:         globs,
+				FixedStrings:  fixedStrings,
+				MaxLineLength: maxLineLength,
+				Rev:           rev,
+			}
+			if err := searchHistory(regexes[0], repoCtx, historyOpts, annotations, tsvWriter); err != nil {
+				return fmt.Errorf("history search failed in %s: %w", repoRoot, err)
+			}
+			continue
+		}
+
+		// Check whether local files match the linked revision
+		var linkChecker *git.LinkChecker
+		if linkStatus || stale != "" {
+			if repoCtx.Rev != "" {
+				linkChecker, err = git.NewRevisionLinkChecker(repoRoot, repoCtx.Remote, repoCtx.Ref, repoCtx.Commit)
+			} else {
+				linkChecker, err = git.NewLinkChecker(repoRoot, repoCtx.Remote, repoCtx.Ref)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to check link status for %s: %w", repoRoot, err)
+			}
+		}
 
 		// Create search options
 		searchOpts := search.SearchOptions{
@@ -109,29 +446,171 @@ func run(cmd *cobra.Command, args []string) error {
 			FixedStrings:  fixedStrings,
 			MaxLineLength: maxLineLength,
 			Encoding:      encoding,
+			BeforeContext: beforeContext,
+			AfterContext:  afterContext,
+		}
+
+		if len(branchPatterns) > 0 {
+			if err := searchBranches(regexes, repoCtx, branchPatterns, searchOpts, permalink, showColumn, annotations, tsvWriter); err != nil {
+				return fmt.Errorf("branch search failed in %s: %w", repoRoot, err)
+			}
+			continue
+		}
+
+		// Restrict the search to files changed since the merge base
+		var changes *git.ChangeSet
+		if changedSince != "" {
+			head := "HEAD"
+			switch {
+			case repoCtx.Rev != "":
+				head = repoCtx.Commit
+			case includeUncommitted:
+				head = ""
+			}
+
+			changes, err = git.GetChanges(repoRoot, changedSince, head)
+			if err != nil {
+				return fmt.Errorf("failed to get changes in %s: %w", repoRoot, err)
+			}
+			searchOpts.Paths = changes.Files
+		}
+
+		// Restrict the search to tracked files
+		// (a revision, history or branch search only sees tracked files anyway)
+		if trackedOnly && repoCtx.Rev == "" {
+			tracked, err := git.ListTrackedFiles(repoRoot)
+			if err != nil {
+				return fmt.Errorf("failed to list tracked files in %s: %w", repoRoot, err)
+			}
+			searchOpts.Paths = intersectPaths(searchOpts.Paths, tracked)
+		}
+
+		// Annotate matches in the revision or the working tree
+		annotateRev := ""
+		if repoCtx.Rev != "" {
+			annotateRev = repoCtx.Commit
+		}
+		annotator, err := newAnnotator(repoRoot, annotateRev, annotations)
+		if err != nil {
+			return fmt.Errorf("failed to load CODEOWNERS for %s: %w", repoRoot, err)
 		}
 
-		// Execute search with callback for real-time output
-		err = search.SearchRepo(pattern, repoRoot, searchOpts, func(match search.Match) error {
+		// Matches in checked-out submodules are attributed to the submodule repository
+		// (a revision only contains the submodule commit, not its files)
+		var submodules []git.Submodule
+		if repoCtx.Rev == "" && !noSubmodules {
+			submodules, err = git.ListSubmodules(repoRoot)
+			if err != nil {
+				// e.g., a gitlink without a .gitmodules entry
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s: submodules are searched as part of the repository: %v\n", repoRoot, err)
+				submodules = nil
+			}
+		}
+		repoTarget := &matchTarget{ctx: repoCtx, annotator: annotator, linkChecker: linkChecker}
+
+		// Submodule targets are resolved on their first match
+		// (nil = the submodule cannot be linked to, so the parent repository is used)
+		submoduleTargets := make(map[string]*matchTarget)
+		resolveSubmodule := func(submodule git.Submodule) *matchTarget {
+			target, resolved := submoduleTargets[submodule.Path]
+			if !resolved {
+				var err error
+				target, err = newSubmoduleTarget(repoRoot, submodule, registry, remotes, linkStatus || stale != "", annotations)
+				if err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s: submodule %s is linked to the parent repository: %v\n", repoRoot, submodule.Path, err)
+					target = nil
+				}
+				submoduleTargets[submodule.Path] = target
+			}
+			return target
+		}
+
+		// Callback for real-time output (rule is nil unless searching with --rules)
+		writeMatch := func(match search.Match, rule *rules.Rule) error {
+			if addedLinesOnly && !changes.IsAdded(match.RelPath, match.LineNumber) {
+				return nil
+			}
+
+			if noSubmodules && repoCtx.Rev == "" && git.IsInNestedRepo(repoRoot, match.RelPath) {
+				return nil
+			}
+
+			target, targetPath := repoTarget, match.RelPath
+			if submodule, found := git.FindSubmodule(submodules, match.RelPath); found {
+				if submoduleTarget := resolveSubmodule(submodule); submoduleTarget != nil {
+					target = submoduleTarget
+					targetPath = strings.TrimPrefix(filepath.ToSlash(match.RelPath), submodule.Path+"/")
+				}
+			}
+			repository := target.ctx.Identity.FullName()
+
 			// Convert match to search result and write immediately
-			localPath := fmt.Sprintf("%s:%d", match.RelPath, match.LineNumber)
-			githubURL := git.BuildGitHubFileURL(
-				repoCtx.Owner,
-				repoCtx.Repo,
-				repoCtx.Branch,
-				match.RelPath,
-				match.LineNumber,
-			)
+			localPath := formatLocalPath(match, showColumn)
+			fileURL := buildMatchURL(target.ctx, target.ctx.Ref, targetPath, match)
 
 			result := output.SearchResult{
 				Repository:  repository,
 				LocalPath:   localPath,
 				MatchedLine: match.LineText,
-				GitHubURL:   githubURL,
+				URL:         fileURL,
+			}
+			if showColumn {
+				result.MatchedText = matchedText(match)
+			}
+			if showContext {
+				result.Context = matchContext(match)
+			}
+			if annotations.Patterns != nil {
+				result.Pattern = matchedPatterns(annotations.Patterns, match)
+			}
+			if worktrees == worktreesLabel {
+				result.Worktree = repoRoot
+				result.Branch = repoCtx.Branch
+			}
+			if rule != nil {
+				result.RuleID = rule.ID
+				result.Severity = rule.Severity
+				result.Message = rule.Message
+				result.Fix = rule.Fix
+			}
+
+			if !target.annotator.annotate(&result, targetPath, match.LineNumber) {
+				return nil
+			}
+
+			if target.linkChecker != nil {
+				status := target.linkChecker.Status(targetPath)
+				result.LinkStatus = string(status)
+
+				if status != git.LinkClean {
+					switch stale {
+					case staleDrop:
+						return nil
+					case staleWarn:
+						fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s %s: link may be stale (%s)\n", repository, localPath, status)
+					}
+				}
 			}
 
 			return tsvWriter.Write(result)
-		})
+		}
+
+		// Execute search on the revision or the working tree
+		searchTree := func(searchRoot string) error {
+			if ruleMatcher != nil {
+				return searchRules(ruleMatcher, searchRoot, searchOpts, writeMatch)
+			}
+			return search.SearchRepoPatterns(regexes, searchRoot, searchOpts, func(match search.Match) error {
+				return writeMatch(match, nil)
+			})
+		}
+		if repoCtx.Rev != "" {
+			// The extracted tree is not a git repository, but its .gitignore files still apply
+			searchOpts.NoRequireGit = true
+			err = withRevisionTree(repoRoot, repoCtx.Commit, searchTree)
+		} else {
+			err = searchTree(repoRoot)
+		}
 		if err != nil {
 			return fmt.Errorf("search failed in %s: %w", repoRoot, err)
 		}
@@ -140,32 +619,618 @@ func run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// getRepoContext retrieves repository context information needed for GitHub URL generation.
-func getRepoContext(repoRoot string) (*RepoContext, error) {
-	// Get GitHub remote URL
-	remoteURL, err := git.GetGitHubRemoteURL(repoRoot)
+// formatLocalPath formats the local path of a match as "path:line",
+// or "path:line:column" with the column of the first submatch if withColumn is true.
+func formatLocalPath(match search.Match, withColumn bool) string {
+	if withColumn && len(match.Submatches) > 0 {
+		return fmt.Sprintf("%s:%d:%d", match.RelPath, match.LineNumber, match.Submatches[0].Column)
+	}
+	return fmt.Sprintf("%s:%d", match.RelPath, match.LineNumber)
+}
+
+// matchedText returns the matched parts of the line.
+func matchedText(match search.Match) []string {
+	var texts []string
+	for _, submatch := range match.Submatches {
+		texts = append(texts, submatch.Text)
+	}
+	return texts
+}
+
+// matchedPatterns returns the comma-separated names of the patterns that matched the line.
+func matchedPatterns(matcher *patterns.Matcher, match search.Match) string {
+	return strings.Join(matcher.Names(matchedText(match), match.LineText), ",")
+}
+
+// buildMatchURL returns the URL of the matched line at ref, or of the line range covering
+// the context lines if there are any.
+func buildMatchURL(repoCtx *RepoContext, ref, relPath string, match search.Match) string {
+	startLine, endLine := match.LineNumber, match.LineNumber
+	if len(match.Before) > 0 {
+		startLine = match.Before[0].LineNumber
+	}
+	if len(match.After) > 0 {
+		endLine = match.After[len(match.After)-1].LineNumber
+	}
+
+	if startLine == endLine {
+		return repoCtx.Provider.BuildFileURL(repoCtx.Identity, ref, relPath, match.LineNumber)
+	}
+	return repoCtx.Provider.BuildLineRangeURL(repoCtx.Identity, ref, relPath, startLine, endLine)
+}
+
+// matchContext returns the lines from the first context line before the match to the last one after it.
+func matchContext(match search.Match) []string {
+	var lines []string
+	for _, line := range match.Before {
+		lines = append(lines, line.Text)
+	}
+	lines = append(lines, match.LineText)
+	for _, line := range match.After {
+		lines = append(lines, line.Text)
+	}
+	return lines
+}
+
+// matchTarget is the repository a match in the working tree is attributed to:
+// the searched repository itself, or a submodule checked out in it.
+type matchTarget struct {
+	ctx         *RepoContext
+	annotator   *annotator
+	linkChecker *git.LinkChecker // nil if link status is not checked
+}
+
+// newSubmoduleTarget resolves the repository context of a checked-out submodule.
+// URLs are pinned to the commit checked out in the submodule.
+func newSubmoduleTarget(repoRoot string, submodule git.Submodule, registry *git.Registry, remotes []string, checkLinks bool, annotations annotationOptions) (*matchTarget, error) {
+	subRoot := filepath.Join(repoRoot, filepath.FromSlash(submodule.Path))
+
+	ctx, err := getRepoContext(subRoot, registry, repoContextOptions{Remotes: remotes, Ref: submodule.Commit})
+	if err != nil {
+		return nil, err
+	}
+
+	annotator, err := newAnnotator(subRoot, "", annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	var linkChecker *git.LinkChecker
+	if checkLinks {
+		linkChecker, err = git.NewLinkChecker(subRoot, ctx.Remote, ctx.Ref)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &matchTarget{ctx: ctx, annotator: annotator, linkChecker: linkChecker}, nil
+}
+
+// searchRevision searches the files of rev, extracted to a temporary directory
+// that is removed after the search.
+func searchRevision(regexes []string, repoRoot, rev string, opts search.SearchOptions, onMatch func(search.Match) error) error {
+	opts.NoRequireGit = true
+	return withRevisionTree(repoRoot, rev, func(searchRoot string) error {
+		return search.SearchRepoPatterns(regexes, searchRoot, opts, onMatch)
+	})
+}
+
+// withRevisionTree extracts the files of rev to a temporary directory, calls fn with it,
+// and removes the directory.
+func withRevisionTree(repoRoot, rev string, fn func(searchRoot string) error) error {
+	searchRoot, err := os.MkdirTemp("", "reporg-rev-")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get remote URL: %w", err)
+		return fmt.Errorf("failed to create temporary directory: %w", err)
 	}
+	defer os.RemoveAll(searchRoot)
 
-	// Parse GitHub URL
-	owner, repo, err := git.ParseGitHubURL(remoteURL)
+	if err := git.ExportRevision(repoRoot, rev, searchRoot); err != nil {
+		return err
+	}
+
+	return fn(searchRoot)
+}
+
+// searchRules searches the files under searchRoot for the patterns of all rules at once,
+// and reports each match once for every rule whose pattern matched it and whose globs select the file.
+func searchRules(matcher *rules.Matcher, searchRoot string, opts search.SearchOptions, onMatch func(search.Match, *rules.Rule) error) error {
+	return search.SearchRepoPatterns(matcher.Patterns(), searchRoot, opts, func(match search.Match) error {
+		for _, rule := range matcher.Rules(filepath.ToSlash(match.RelPath), matchedText(match), match.LineText) {
+			if err := onMatch(match, rule); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// readReposFile reads repository paths from the file, or from stdin if the file is "-".
+// Each line is a path; leading and trailing spaces, blank lines and lines starting with "#" are ignored.
+func readReposFile(reposFile string, stdin io.Reader) ([]string, error) {
+	r := stdin
+	if reposFile != "-" {
+		file, err := os.Open(reposFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open repos file: %w", err)
+		}
+		defer file.Close()
+		r = file
+	}
+
+	var paths []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		paths = append(paths, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read repos file: %w", err)
+	}
+
+	return paths, nil
+}
+
+// repoSelected reports whether the repository passes the --include-repo and --exclude-repo filters.
+// A pattern containing "/" is matched against the full name ("owner/name"), otherwise against the name.
+func repoSelected(identity git.RepoIdentity, includes, excludes []string) bool {
+	matches := func(patterns []string) bool {
+		for _, p := range patterns {
+			target := identity.Repo
+			if strings.Contains(p, "/") {
+				target = identity.FullName()
+			}
+			if matched, _ := path.Match(p, target); matched {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(includes) > 0 && !matches(includes) {
+		return false
+	}
+	return !matches(excludes)
+}
+
+// intersectPaths returns the paths in both lists, keeping the order of b.
+// A nil list means all files, so the other list is returned as is.
+func intersectPaths(a, b []string) []string {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	inA := make(map[string]bool, len(a))
+	for _, path := range a {
+		inA[path] = true
+	}
+
+	result := []string{}
+	for _, path := range b {
+		if inA[path] {
+			result = append(result, path)
+		}
+	}
+	return result
+}
+
+// searchHistory searches the commit history of the repository and writes a row for each
+// added or removed line that matches the pattern.
+// Added lines link to the file at the commit, and removed lines link to the commit.
+func searchHistory(pattern string, repoCtx *RepoContext, opts history.SearchOptions, annotations annotationOptions, tsvWriter *output.TSVWriter) error {
+	repository := repoCtx.Identity.FullName()
+
+	// Owners are resolved with the CODEOWNERS file of the starting revision
+	annotateRev := ""
+	if repoCtx.Rev != "" {
+		annotateRev = repoCtx.Commit
+	}
+	annotator, err := newAnnotator(repoCtx.Root, annotateRev, annotations)
+	if err != nil {
+		return err
+	}
+
+	return history.SearchHistory(pattern, repoCtx.Root, opts, func(match history.Match) error {
+		result := output.SearchResult{
+			Repository:  repository,
+			LocalPath:   fmt.Sprintf("%s:%d", match.RelPath, match.LineNumber),
+			MatchedLine: match.LineText,
+			Commit:      match.Commit,
+			Author:      match.Author,
+			Date:        match.Date,
+		}
+
+		if match.Removed {
+			result.URL = repoCtx.Provider.BuildCommitURL(repoCtx.Identity, match.Commit)
+			result.Change = "removed"
+		} else {
+			result.URL = repoCtx.Provider.BuildFileURL(repoCtx.Identity, match.Commit, match.RelPath, match.LineNumber)
+			result.Change = "added"
+		}
+
+		if !annotator.annotate(&result, match.RelPath, match.LineNumber) {
+			return nil
+		}
+
+		return tsvWriter.Write(result)
+	})
+}
+
+// annotator adds blame and CODEOWNERS information to the results of a revision or the working tree.
+type annotator struct {
+	opts    annotationOptions
+	blamer  *git.Blamer         // nil if blame is disabled
+	ruleset *codeowners.Ruleset // nil if CODEOWNERS is not used
+}
+
+// newAnnotator creates an annotator for files at rev, or in the working tree if rev is empty.
+func newAnnotator(repoRoot, rev string, opts annotationOptions) (*annotator, error) {
+	a := &annotator{opts: opts}
+
+	if opts.Blame {
+		a.blamer = git.NewBlamer(repoRoot, rev)
+	}
+
+	if opts.Codeowners || len(opts.Owners) > 0 {
+		ruleset, err := loadCodeowners(repoRoot, rev)
+		if err != nil {
+			return nil, err
+		}
+		a.ruleset = ruleset
+	}
+
+	return a, nil
+}
+
+// annotate sets the optional columns of the result for the matched line.
+// It returns false if the match is excluded by the owner filter.
+func (a *annotator) annotate(result *output.SearchResult, relPath string, lineNum int) bool {
+	if a.ruleset != nil {
+		owners := a.ruleset.Owners(relPath)
+		if len(a.opts.Owners) > 0 && !containsOwner(owners, a.opts.Owners) {
+			return false
+		}
+		result.Owners = strings.Join(owners, ",")
+	}
+
+	if a.blamer != nil {
+		if info, ok := a.blamer.Blame(relPath, lineNum); ok {
+			result.Author = info.Author
+			result.AuthorEmail = info.AuthorEmail
+			result.Commit = info.Commit
+			result.CommitDate = info.CommitDate
+		}
+	}
+
+	return true
+}
+
+// containsOwner reports whether owners contains any of the wanted owners.
+// Owners are compared case-insensitively, as GitHub user and team names are.
+func containsOwner(owners, wanted []string) bool {
+	for _, owner := range owners {
+		for _, w := range wanted {
+			if strings.EqualFold(owner, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// loadCodeowners reads the CODEOWNERS file of the repository at rev, or of the working tree if rev is empty.
+func loadCodeowners(repoRoot, rev string) (*codeowners.Ruleset, error) {
+	if rev == "" {
+		return codeowners.Load(repoRoot)
+	}
+
+	for _, path := range codeowners.Paths {
+		content, err := git.ReadFileAtRevision(repoRoot, rev, path)
+		if err == nil {
+			return codeowners.Parse(bytes.NewReader(content))
+		}
+	}
+
+	return &codeowners.Ruleset{}, nil
+}
+
+// branchHit is a match found on one or more branches.
+type branchHit struct {
+	result   output.SearchResult // Result annotated on the first branch containing the match
+	refs     []string            // Branches containing the match
+	urls     []string            // Unique URLs to the match on each branch
+	excluded bool                // Excluded by the owner filter
+}
+
+// searchBranches searches the tree of each branch matching the patterns and writes the results.
+// Identical matches (same file, line number and content) found on several branches are
+// collapsed into one row listing the branches in the ref column and their URLs in the url column.
+func searchBranches(regexes []string, repoCtx *RepoContext, branchPatterns []string, opts search.SearchOptions, permalink, showColumn bool, annotations annotationOptions, tsvWriter *output.TSVWriter) error {
+	branches, err := git.ListBranches(repoCtx.Root, branchPatterns)
+	if err != nil {
+		return err
+	}
+
+	// Group branches by commit so that each tree is searched only once
+	var commits []string
+	branchesByCommit := make(map[string][]git.Branch)
+	for _, branch := range branches {
+		if _, ok := branchesByCommit[branch.Commit]; !ok {
+			commits = append(commits, branch.Commit)
+		}
+		branchesByCommit[branch.Commit] = append(branchesByCommit[branch.Commit], branch)
+	}
+
+	repository := repoCtx.Identity.FullName()
+
+	var hits []*branchHit
+	hitsByKey := make(map[string]*branchHit)
+
+	for _, commit := range commits {
+		commitBranches := branchesByCommit[commit]
+		annotator, err := newAnnotator(repoCtx.Root, commit, annotations)
+		if err != nil {
+			return err
+		}
+
+		err = searchRevision(regexes, repoCtx.Root, commit, opts, func(match search.Match) error {
+			key := fmt.Sprintf("%s:%d:%s", match.RelPath, match.LineNumber, match.LineText)
+			hit, ok := hitsByKey[key]
+			if !ok {
+				hit = &branchHit{
+					result: output.SearchResult{
+						Repository:  repository,
+						LocalPath:   formatLocalPath(match, showColumn),
+						MatchedLine: match.LineText,
+					},
+				}
+				if showColumn {
+					hit.result.MatchedText = matchedText(match)
+				}
+				if opts.BeforeContext > 0 || opts.AfterContext > 0 {
+					hit.result.Context = matchContext(match)
+				}
+				if annotations.Patterns != nil {
+					hit.result.Pattern = matchedPatterns(annotations.Patterns, match)
+				}
+				hit.excluded = !annotator.annotate(&hit.result, match.RelPath, match.LineNumber)
+				hitsByKey[key] = hit
+				hits = append(hits, hit)
+			}
+
+			for _, branch := range commitBranches {
+				hit.refs = append(hit.refs, branch.Name)
+
+				url := buildMatchURL(repoCtx, branchURLRef(branch, repoCtx.Remote, permalink), match.RelPath, match)
+				if !slices.Contains(hit.urls, url) {
+					hit.urls = append(hit.urls, url)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, hit := range hits {
+		if hit.excluded {
+			continue
+		}
+
+		result := hit.result
+		result.URL = strings.Join(hit.urls, " ")
+		result.Ref = strings.Join(hit.refs, ",")
+		if err := tsvWriter.Write(result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// branchURLRef returns the ref to use in URLs for a branch.
+// Local branches and branches of the selected remote use the branch name on the remote,
+// and branches of other remotes (or all branches if permalink is true) use the commit SHA.
+func branchURLRef(branch git.Branch, remote string, permalink bool) string {
+	if permalink || (branch.Remote != "" && branch.Remote != remote) {
+		return branch.Commit
+	}
+	return branch.RemoteBranch()
+}
+
+// getRepoContext retrieves repository context information needed for URL generation.
+// The ref used in URLs is determined in the following order:
+//  1. opts.Ref (the remote's default branch if defaultBranchRef)
+//  2. when opts.Rev is set: the tag or branch name of the revision,
+//     or its commit SHA if opts.Permalink is true or it is not a tag or branch
+//  3. HEAD commit SHA if opts.Permalink is true or HEAD is detached
+//  4. upstream branch name if the current branch tracks a branch on the selected remote,
+//     otherwise the current branch name
+//  5. default branch of the remote, then "main"
+func getRepoContext(repoRoot string, registry *git.Registry, opts repoContextOptions) (*RepoContext, error) {
+	// Select the remote to link to
+	remote, provider, identity, err := selectRemote(repoRoot, registry, opts.Remotes)
 	if err != nil {
-		return nil, fmt.Errorf("not a GitHub repository: %w", err)
+		return nil, err
 	}
 
 	// Determine branch name
-	// Try to get current branch
+	// Try to get current branch (empty if detached HEAD)
 	branch, err := git.GetCurrentBranch(repoRoot)
-	if err != nil || branch == "" {
-		// Fallback to "main"
-		branch = "main"
+	if err != nil {
+		branch = ""
+	}
+
+	// Get HEAD commit (fails if the repository has no commits yet)
+	commit, err := git.GetHeadCommit(repoRoot)
+	if err != nil {
+		commit = ""
+	}
+
+	// Use the commit of the searched revision instead of HEAD
+	if opts.Rev != "" {
+		commit, err = git.ResolveCommit(repoRoot, opts.Rev)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Determine ref for URLs
+	var ref string
+	switch {
+	case opts.Ref == defaultBranchRef:
+		ref, err = git.GetDefaultBranch(repoRoot, remote)
+		if err != nil {
+			return nil, err
+		}
+	case opts.Ref != "":
+		ref = opts.Ref
+	case opts.Rev != "":
+		ref = commit
+		if !opts.Permalink {
+			if name := git.RevisionRefName(repoRoot, remote, opts.Rev); name != "" {
+				ref = name
+			}
+		}
+	case commit != "" && (opts.Permalink || branch == ""):
+		ref = commit
+	default:
+		ref = branch
+
+		// Prefer the name of the upstream branch on the selected remote
+		upstreamRemote, upstreamBranch, err := git.GetUpstream(repoRoot)
+		if err == nil && upstreamRemote == remote && upstreamBranch != "" {
+			ref = upstreamBranch
+		}
+	}
+	if ref == "" {
+		// Fallback to the remote's default branch, then "main"
+		ref, err = git.GetDefaultBranch(repoRoot, remote)
+		if err != nil || ref == "" {
+			ref = "main"
+		}
 	}
 
 	return &RepoContext{
-		Root:   repoRoot,
-		Owner:  owner,
-		Repo:   repo,
-		Branch: branch,
+		Root:     repoRoot,
+		Remote:   remote,
+		Provider: provider,
+		Identity: identity,
+		Branch:   branch,
+		Commit:   commit,
+		Rev:      opts.Rev,
+		Ref:      ref,
 	}, nil
 }
+
+// selectRemote selects the remote to link to and resolves its hosting provider.
+// Remotes in preferences are tried in order. If preferences is empty, origin is tried first,
+// followed by the other remotes in the order git lists them.
+func selectRemote(repoRoot string, registry *git.Registry, preferences []string) (string, git.Provider, git.RepoIdentity, error) {
+	candidates := preferences
+	if len(candidates) == 0 {
+		remotes, err := git.ListRemotes(repoRoot)
+		if err != nil {
+			return "", nil, git.RepoIdentity{}, err
+		}
+		candidates = remotes
+		if slices.Contains(remotes, "origin") {
+			candidates = append([]string{"origin"}, remotes...)
+		}
+	}
+
+	var firstErr error
+	tried := make(map[string]bool)
+	for _, remote := range candidates {
+		if remote == trackingRemote {
+			tracking, err := git.GetTrackingRemote(repoRoot)
+			if err != nil || tracking == "" {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("current branch has no upstream remote")
+				}
+				continue
+			}
+			remote = tracking
+		}
+
+		if tried[remote] {
+			continue
+		}
+		tried[remote] = true
+
+		// Get remote URLs (fetch and push) after applying insteadOf rewrites
+		remoteURLs, err := git.ResolveRemoteURLs(repoRoot, remote)
+		if err == nil {
+			// Find the hosting provider for the first remote URL that can be resolved
+			var provider git.Provider
+			var identity git.RepoIdentity
+			provider, identity, err = resolveProvider(registry, remoteURLs)
+			if err == nil {
+				return remote, provider, identity, nil
+			}
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if firstErr == nil {
+		firstErr = fmt.Errorf("no remote configured")
+	}
+	return "", nil, git.RepoIdentity{}, firstErr
+}
+
+// resolveProvider returns the provider and repository identity for the first resolvable remote URL.
+func resolveProvider(registry *git.Registry, remoteURLs []string) (git.Provider, git.RepoIdentity, error) {
+	var firstErr error
+	for _, remoteURL := range remoteURLs {
+		provider, identity, err := registry.Resolve(remoteURL)
+		if err == nil {
+			return provider, identity, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return nil, git.RepoIdentity{}, firstErr
+}
+
+// buildRegistry creates the provider registry from the built-in providers
+// and the additional hosts declared in the config file and --host flags.
+func buildRegistry(configFile string, hostFlags []string) (*git.Registry, error) {
+	var hosts []config.HostConfig
+
+	if configFile != "" {
+		cfg, err := config.Load(configFile)
+		if err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, cfg.Hosts...)
+	}
+
+	for _, hostFlag := range hostFlags {
+		host, err := config.ParseHostFlag(hostFlag)
+		if err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, host)
+	}
+
+	registry := git.DefaultRegistry()
+	for _, host := range hosts {
+		provider, err := git.NewProvider(host.Type, host.Host, host.BaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid host configuration for %s: %w", host.Host, err)
+		}
+		registry.Register(provider)
+	}
+
+	return registry, nil
+}